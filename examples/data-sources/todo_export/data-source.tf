@@ -0,0 +1,14 @@
+# Render the release checklist as a Markdown task list
+data "todo_export" "example" {
+  filter = {
+    description_prefix = "[release-1.2]"
+  }
+  format  = "markdown"
+  sort_by = "completed"
+  header  = "## Release 1.2 ({{ .Completed }} of {{ .Total }} done)\n"
+}
+
+resource "local_file" "checklist" {
+  filename = "${path.module}/CHECKLIST.md"
+  content  = data.todo_export.example.content
+}
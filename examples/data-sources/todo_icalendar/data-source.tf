@@ -0,0 +1,15 @@
+# Publish the open release todos as a calendar
+data "todo_icalendar" "example" {
+  filter = {
+    description_prefix = "[release-1.2]"
+    completed          = false
+  }
+  uid_domain    = "todo.example.com"
+  calendar_name = "Release 1.2"
+  dtstamp       = "2024-03-01T00:00:00Z"
+}
+
+resource "local_file" "calendar" {
+  filename = "${path.module}/release.ics"
+  content  = data.todo_icalendar.example.content
+}
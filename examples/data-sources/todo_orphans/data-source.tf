@@ -0,0 +1,4 @@
+# List todos left behind by creates that failed more than an hour ago
+data "todo_orphans" "example" {
+  min_age = "1h"
+}
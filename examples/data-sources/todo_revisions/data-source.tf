@@ -0,0 +1,4 @@
+# Fetch the changes Terraform made to a todo
+data "todo_revisions" "example" {
+  id = todo_todo.example.id
+}
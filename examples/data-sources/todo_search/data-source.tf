@@ -0,0 +1,6 @@
+# Find the todos that best match a half-remembered description
+data "todo_search" "example" {
+  query     = "renew certficate"
+  limit     = 5
+  min_score = 0.6
+}
@@ -0,0 +1,15 @@
+# Read what the provider learned about the Todo server
+data "todo_server" "example" {}
+
+# Require the v1 API before managing todos
+resource "todo_todo" "example" {
+  description = "Go Shopping"
+  completed   = false
+
+  lifecycle {
+    precondition {
+      condition     = data.todo_server.example.api_version == "v1"
+      error_message = "The Todo server at ${data.todo_server.example.endpoint} does not speak the v1 API."
+    }
+  }
+}
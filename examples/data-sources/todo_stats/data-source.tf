@@ -0,0 +1,12 @@
+# Count the todos on the release checklist
+data "todo_stats" "example" {
+  description_prefix = "[release-1.2]"
+}
+
+# Warn while the release checklist is unfinished
+check "release_checklist" {
+  assert {
+    condition     = data.todo_stats.example.open == 0
+    error_message = "${data.todo_stats.example.open} release todos are still open."
+  }
+}
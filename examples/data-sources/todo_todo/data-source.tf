@@ -2,3 +2,26 @@
 data "todo_todo" "example" {
   id = 1
 }
+
+# Read in the only Todo item with a description starting with a prefix
+data "todo_todo" "by_description" {
+  description_match = {
+    value = "[release-1.2] Tag"
+    mode  = "prefix"
+  }
+}
+
+# Create a Todo item only if one does not exist yet
+data "todo_todo" "maybe" {
+  description_match = {
+    value = "Renew the TLS certificate"
+  }
+  fail_if_missing = false
+}
+
+resource "todo_todo" "renewal" {
+  count = data.todo_todo.maybe.exists ? 0 : 1
+
+  description = "Renew the TLS certificate"
+  completed   = false
+}
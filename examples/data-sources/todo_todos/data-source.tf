@@ -0,0 +1,7 @@
+# Read in every open todo for the current sprint
+data "todo_todos" "example" {
+  filter = {
+    description_prefix = "[sprint-42]"
+    completed          = false
+  }
+}
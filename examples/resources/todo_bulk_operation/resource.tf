@@ -0,0 +1,14 @@
+# Complete every open "[sprint-42]" todo
+resource "todo_bulk_operation" "close_sprint" {
+  filter = {
+    description_prefix = "[sprint-42]"
+    completed          = false
+  }
+  action      = "complete"
+  parallelism = 5
+
+  # Change the trigger to run the operation again
+  triggers = {
+    sprint_end = "2024-06-28"
+  }
+}
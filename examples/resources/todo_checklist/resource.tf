@@ -0,0 +1,14 @@
+# Track the release steps, with a parent todo completed once they are all done
+resource "todo_checklist" "release" {
+  title = "Release 1.2"
+  steps = [
+    "Tag the release",
+    "Publish the binaries",
+    "Announce the release",
+  ]
+  create_parent = true
+}
+
+output "release_progress" {
+  value = "${todo_checklist.release.progress_percent}% done, next: ${coalesce(todo_checklist.release.next_step, "nothing")}"
+}
@@ -0,0 +1,9 @@
+# Check off the release todo created by the release manager once the
+# pipeline applies this configuration
+variable "release_todo_id" {
+  type = number
+}
+
+resource "todo_completion" "release" {
+  todo_id = var.release_todo_id
+}
@@ -0,0 +1,17 @@
+# Delete any "[platform]" todo that was not created by this configuration
+resource "todo_todos" "platform" {
+  items = {
+    upgrade = {
+      description = "[platform] Upgrade the cluster"
+      completed   = false
+    }
+  }
+}
+
+# The allowed todos have to exist before anything can be deleted, so create
+# them first with: terraform apply -target=todo_todos.platform
+resource "todo_exclusive" "platform" {
+  description_prefix = "[platform]"
+  allowed_ids        = [for item in todo_todos.platform.items : item.id]
+  max_deletions      = 5
+}
@@ -0,0 +1,8 @@
+# Keep a todo for every task exported from a calendar app
+resource "todo_icalendar_import" "example" {
+  content = file("${path.module}/tasks.ics")
+}
+
+output "todo_ids_by_uid" {
+  value = todo_icalendar_import.example.uid_map
+}
@@ -0,0 +1,9 @@
+# Keep two pipelines from migrating the database at the same time
+resource "todo_lock" "migrate" {
+  name    = "database-migration"
+  holder  = "deploy pipeline"
+  timeout = "10m"
+
+  # Break locks left behind by pipelines that were killed
+  ttl = "2h"
+}
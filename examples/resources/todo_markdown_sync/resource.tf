@@ -0,0 +1,4 @@
+# Keep a todo for every item of the release runbook checklist
+resource "todo_markdown_sync" "example" {
+  content = file("${path.module}/RUNBOOK.md")
+}
@@ -0,0 +1,10 @@
+# Copy the migration todos from the old Todo server to the one the provider
+# manages, keeping them up to date on every apply
+resource "todo_mirror" "migration" {
+  source = {
+    host = "todo-old.example.com"
+    port = "8080"
+  }
+
+  description_prefix = "migration: "
+}
@@ -0,0 +1,8 @@
+# Delete todos left behind by failed creates, again whenever the run ID changes
+resource "todo_orphan_cleanup" "example" {
+  min_age = "1h"
+
+  triggers = {
+    run = var.run_id
+  }
+}
@@ -0,0 +1,6 @@
+# A new todo every Monday morning, or as soon as the last one is completed
+resource "todo_recurring" "rotate_credentials" {
+  description_template = "Rotate credentials (week of {{ .Date }})"
+  schedule             = "0 9 * * 1"
+  history_limit        = 4
+}
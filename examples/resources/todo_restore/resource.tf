@@ -0,0 +1,16 @@
+# Put the Todo server back the way it was in a snapshot
+variable "rolled_back_release" {
+  type = string
+}
+
+resource "todo_restore" "rollback" {
+  path = "${path.module}/todos.snapshot.json"
+
+  # Keep todos that were added after the snapshot was taken
+  prune = false
+
+  # Restore again whenever another release is rolled back
+  triggers = {
+    release = var.rolled_back_release
+  }
+}
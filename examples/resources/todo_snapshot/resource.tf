@@ -0,0 +1,12 @@
+# Back up the Todo server before every release
+variable "release" {
+  type = string
+}
+
+resource "todo_snapshot" "backup" {
+  path = "${path.module}/todos.snapshot.json"
+
+  triggers = {
+    release = var.release
+  }
+}
@@ -3,3 +3,11 @@ resource "todo_todo" "test" {
   description = "Go Shopping"
   completed   = false
 }
+
+# A reminder that deletes itself on the first apply after it expires
+resource "todo_todo" "review_ci_run" {
+  description = "Review the nightly CI run"
+  completed   = false
+  expires_at  = "2024-06-30T17:00:00Z"
+  on_expiry   = "delete"
+}
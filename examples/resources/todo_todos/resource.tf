@@ -0,0 +1,21 @@
+# Manage the onboarding todos for every new hire as a single resource
+variable "new_hires" {
+  type    = set(string)
+  default = ["alice", "bob"]
+}
+
+resource "todo_todos" "onboarding" {
+  items = merge([
+    for hire in var.new_hires : {
+      "${hire}-laptop" = {
+        description = "Order a laptop for ${hire}"
+        completed   = false
+      }
+      "${hire}-accounts" = {
+        description = "Create accounts for ${hire}"
+        completed   = false
+      }
+    }
+  ]...)
+  parallelism = 5
+}
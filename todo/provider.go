@@ -2,7 +2,10 @@ package todo
 
 import (
 	"context"
+	"errors"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/go-openapi/runtime"
 	httptransport "github.com/go-openapi/runtime/client"
@@ -10,9 +13,9 @@ import (
 
 	// Todo API Libraries
 	"github.com/spkane/todo-for-terraform/client"
-	"github.com/spkane/todo-for-terraform/client/todos"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
@@ -28,18 +31,38 @@ var (
 
 // New is a helper function to simplify provider server and testing implementation.
 func New() provider.Provider {
-	return &todoProvider{}
+	return &todoProvider{
+		descriptionNormalizers: &descriptionNormalizers{},
+	}
 }
 
 // todoProvider is the provider implementation.
-type todoProvider struct{}
+type todoProvider struct {
+	descriptionNormalizers *descriptionNormalizers
+}
 
 // todoProviderModel maps provider schema data to a Go type.
 type todoProviderModel struct {
-	Host    types.String `tfsdk:"host"`
-	Port    types.String `tfsdk:"port"`
-	Schema  types.String `tfsdk:"schema"`
-	APIPath types.String `tfsdk:"apipath"`
+	Host                     types.String `tfsdk:"host"`
+	Port                     types.String `tfsdk:"port"`
+	Schema                   types.String `tfsdk:"schema"`
+	APIPath                  types.String `tfsdk:"apipath"`
+	IDReuseAction            types.String `tfsdk:"id_reuse_action"`
+	DescriptionNormalization types.String `tfsdk:"description_normalization"`
+	EncryptionKey            types.String `tfsdk:"encryption_key"`
+	PreviousEncryptionKeys   types.List   `tfsdk:"previous_encryption_keys"`
+	ConsistencyTimeout       types.String `tfsdk:"consistency_timeout"`
+}
+
+// todoProviderData is handed to data sources and resources during their
+// Configure methods.
+type todoProviderData struct {
+	client                *client.TodoList
+	idReuseAction         string
+	keyring               *todoKeyring
+	consistencyTimeout    time.Duration
+	serverProbe           *todoServerProbe
+	descriptionNormalizer *descriptionNormalizer
 }
 
 // Metadata returns the provider type name.
@@ -67,6 +90,29 @@ func (p *todoProvider) Schema(_ context.Context, _ provider.SchemaRequest, resp
 				Optional:    true,
 				Description: "The URL path for the Todo server API (e.g. '/'). May also be provided via TODO_APIPATH environment variable.",
 			},
+			"id_reuse_action": schema.StringAttribute{
+				Optional:    true,
+				Description: "What to do when a managed todo's ID now belongs to an unrelated item on the Todo server: 'error' fails the refresh, 'remove' drops it from state so it is recreated (default: 'error'). An unrelated item is recognised by the creation run each todo_todo publishes to the server with its revision log, so editing a todo's description outside Terraform is refreshed as drift. Todos Terraform has not published a revision log for yet, such as imported ones, are recognised by their description instead. May also be provided via TODO_ID_REUSE_ACTION environment variable.",
+			},
+			"description_normalization": schema.StringAttribute{
+				Optional:    true,
+				Description: "How todo descriptions read back from the Todo server are compared to the ones in state: 'exact', 'unicode' to ignore trailing whitespace and Unicode normalization forms, or 'case_insensitive' to also ignore case (default: 'exact'). May also be provided via TODO_DESCRIPTION_NORMALIZATION environment variable.",
+			},
+			"encryption_key": schema.StringAttribute{
+				Optional:    true,
+				Sensitive:   true,
+				Description: "A base64 encoded 16, 24 or 32 byte AES key used to encrypt todo descriptions before they are sent to the Todo server. Descriptions that are not encrypted are still read as plaintext. May also be provided via TODO_ENCRYPTION_KEY environment variable.",
+			},
+			"previous_encryption_keys": schema.ListAttribute{
+				Optional:    true,
+				Sensitive:   true,
+				ElementType: types.StringType,
+				Description: "Base64 encoded AES keys that were previously used as the encryption_key. They are only used to decrypt descriptions after a key rotation. May also be provided as a comma separated list via TODO_PREVIOUS_ENCRYPTION_KEYS environment variable.",
+			},
+			"consistency_timeout": schema.StringAttribute{
+				Optional:    true,
+				Description: "How long to poll a todo after it is created or updated until it reads back as written, for servers behind caching proxies (e.g. '30s'). Use '0s' to trust the first read (default: '10s'). May also be provided via TODO_CONSISTENCY_TIMEOUT environment variable.",
+			},
 		},
 		Blocks:      map[string]schema.Block{},
 		Description: "Interface with the Todo API server (github.com/spkane/todo-for-terraform)",
@@ -74,8 +120,6 @@ func (p *todoProvider) Schema(_ context.Context, _ provider.SchemaRequest, resp
 }
 
 // Configure prepares a Todo API client for data sources and resources.
-//
-//gocyclo:ignore
 func (p *todoProvider) Configure(ctx context.Context, req provider.ConfigureRequest, resp *provider.ConfigureResponse) {
 	tflog.Info(ctx, "Configuring Todo client")
 
@@ -89,9 +133,38 @@ func (p *todoProvider) Configure(ctx context.Context, req provider.ConfigureRequ
 
 	// If practitioner provided a configuration value for any of the
 	// attributes, it must be a known value.
+	resp.Diagnostics.Append(checkKnownConfig(config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	settings, diags := newTodoProviderSettings(ctx, config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	providerData, diags := newTodoProviderData(ctx, settings)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Make the Todo client available during DataSource and Resource
+	// type Configure methods.
+	p.descriptionNormalizers.set(providerData.descriptionNormalizer)
+	resp.DataSourceData = providerData
+	resp.ResourceData = providerData
+
+	tflog.Info(ctx, "Configured Todo client", map[string]any{"success": true})
+}
+
+// checkKnownConfig reports configuration values that are unknown.
+func checkKnownConfig(config todoProviderModel) diag.Diagnostics {
+	var diags diag.Diagnostics
 
 	if config.Host.IsUnknown() {
-		resp.Diagnostics.AddAttributeError(
+		diags.AddAttributeError(
 			path.Root("host"),
 			"Unknown Todo API Host",
 			"The provider cannot create the Todo API client as there is an unknown configuration value for the Todo API host. "+
@@ -100,7 +173,7 @@ func (p *todoProvider) Configure(ctx context.Context, req provider.ConfigureRequ
 	}
 
 	if config.Port.IsUnknown() {
-		resp.Diagnostics.AddAttributeError(
+		diags.AddAttributeError(
 			path.Root("port"),
 			"Unknown Todo API Port",
 			"The provider cannot create the Todo API client as there is an unknown configuration value for the Todo API port. "+
@@ -109,7 +182,7 @@ func (p *todoProvider) Configure(ctx context.Context, req provider.ConfigureRequ
 	}
 
 	if config.Schema.IsUnknown() {
-		resp.Diagnostics.AddAttributeError(
+		diags.AddAttributeError(
 			path.Root("schema"),
 			"Unknown Todo API Schema",
 			"The provider cannot create the Todo API client as there is an unknown configuration value for the Todo API schema. "+
@@ -118,7 +191,7 @@ func (p *todoProvider) Configure(ctx context.Context, req provider.ConfigureRequ
 	}
 
 	if config.APIPath.IsUnknown() {
-		resp.Diagnostics.AddAttributeError(
+		diags.AddAttributeError(
 			path.Root("apipath"),
 			"Unknown Todo API API Path",
 			"The provider cannot create the Todo API client as there is an unknown configuration value for the Todo API path. "+
@@ -126,134 +199,340 @@ func (p *todoProvider) Configure(ctx context.Context, req provider.ConfigureRequ
 		)
 	}
 
-	if resp.Diagnostics.HasError() {
-		return
+	if config.IDReuseAction.IsUnknown() {
+		diags.AddAttributeError(
+			path.Root("id_reuse_action"),
+			"Unknown Todo ID Reuse Action",
+			"The provider cannot create the Todo API client as there is an unknown configuration value for the ID reuse action. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the TODO_ID_REUSE_ACTION environment variable.",
+		)
+	}
+
+	if config.DescriptionNormalization.IsUnknown() {
+		diags.AddAttributeError(
+			path.Root("description_normalization"),
+			"Unknown Todo Description Normalization",
+			"The provider cannot create the Todo API client as there is an unknown configuration value for the description normalization. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the TODO_DESCRIPTION_NORMALIZATION environment variable.",
+		)
+	}
+
+	if config.EncryptionKey.IsUnknown() {
+		diags.AddAttributeError(
+			path.Root("encryption_key"),
+			"Unknown Todo Encryption Key",
+			"The provider cannot create the Todo API client as there is an unknown configuration value for the encryption key. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the TODO_ENCRYPTION_KEY environment variable.",
+		)
+	}
+
+	if config.PreviousEncryptionKeys.IsUnknown() {
+		diags.AddAttributeError(
+			path.Root("previous_encryption_keys"),
+			"Unknown Todo Previous Encryption Keys",
+			"The provider cannot create the Todo API client as there is an unknown configuration value for the previous encryption keys. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the TODO_PREVIOUS_ENCRYPTION_KEYS environment variable.",
+		)
+	}
+
+	if config.ConsistencyTimeout.IsUnknown() {
+		diags.AddAttributeError(
+			path.Root("consistency_timeout"),
+			"Unknown Todo Consistency Timeout",
+			"The provider cannot create the Todo API client as there is an unknown configuration value for the consistency timeout. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the TODO_CONSISTENCY_TIMEOUT environment variable.",
+		)
 	}
+	return diags
+}
 
-	// Default values to environment variables, but override
-	// with Terraform configuration value if set.
+// todoProviderSettings are the provider settings, resolved from the
+// configuration and the environment.
+type todoProviderSettings struct {
+	host                     string
+	port                     string
+	schema                   string
+	apipath                  string
+	idReuseAction            string
+	descriptionNormalization string
+	encryptionKey            string
+	previousEncryptionKeys   []string
+	consistencyTimeout       string
+}
 
-	host := os.Getenv("TODO_HOST")
-	port := os.Getenv("TODO_PORT")
-	schema := os.Getenv("TODO_SCHEMA")
-	apipath := os.Getenv("TODO_APIPATH")
+// newTodoProviderSettings resolves the provider settings. Values default to
+// environment variables, but are overridden with Terraform configuration
+// values if set. Missing endpoint settings fall back to defaults with a
+// warning, and invalid settings are reported as errors.
+func newTodoProviderSettings(ctx context.Context, config todoProviderModel) (todoProviderSettings, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	settings := todoProviderSettings{
+		host:                     os.Getenv("TODO_HOST"),
+		port:                     os.Getenv("TODO_PORT"),
+		schema:                   os.Getenv("TODO_SCHEMA"),
+		apipath:                  os.Getenv("TODO_APIPATH"),
+		idReuseAction:            os.Getenv("TODO_ID_REUSE_ACTION"),
+		descriptionNormalization: os.Getenv("TODO_DESCRIPTION_NORMALIZATION"),
+		encryptionKey:            os.Getenv("TODO_ENCRYPTION_KEY"),
+		consistencyTimeout:       os.Getenv("TODO_CONSISTENCY_TIMEOUT"),
+	}
+	if keys := os.Getenv("TODO_PREVIOUS_ENCRYPTION_KEYS"); keys != "" {
+		settings.previousEncryptionKeys = strings.Split(keys, ",")
+	}
 
 	if !config.Host.IsNull() {
-		host = config.Host.ValueString()
+		settings.host = config.Host.ValueString()
 	}
 
 	if !config.Port.IsNull() {
-		port = config.Port.ValueString()
+		settings.port = config.Port.ValueString()
 	}
 
 	if !config.Schema.IsNull() {
-		schema = config.Schema.ValueString()
+		settings.schema = config.Schema.ValueString()
 	}
 
 	if !config.APIPath.IsNull() {
-		apipath = config.APIPath.ValueString()
+		settings.apipath = config.APIPath.ValueString()
 	}
 
-	// If any of the expected configurations are missing, return
-	// errors with provider-specific guidance.
+	if !config.IDReuseAction.IsNull() {
+		settings.idReuseAction = config.IDReuseAction.ValueString()
+	}
+
+	if !config.DescriptionNormalization.IsNull() {
+		settings.descriptionNormalization = config.DescriptionNormalization.ValueString()
+	}
+
+	if !config.EncryptionKey.IsNull() {
+		settings.encryptionKey = config.EncryptionKey.ValueString()
+	}
+
+	if !config.ConsistencyTimeout.IsNull() {
+		settings.consistencyTimeout = config.ConsistencyTimeout.ValueString()
+	}
+
+	if !config.PreviousEncryptionKeys.IsNull() {
+		settings.previousEncryptionKeys = nil
+		diags.Append(config.PreviousEncryptionKeys.ElementsAs(ctx, &settings.previousEncryptionKeys, false)...)
+		if diags.HasError() {
+			return settings, diags
+		}
+	}
 
-	if host == "" {
-		resp.Diagnostics.AddAttributeWarning(
+	diags.Append(settings.setEndpointDefaults()...)
+	diags.Append(settings.validate()...)
+	return settings, diags
+}
+
+// setEndpointDefaults fills in missing endpoint settings with their default
+// values, warning about each of them.
+func (s *todoProviderSettings) setEndpointDefaults() diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if s.host == "" {
+		diags.AddAttributeWarning(
 			path.Root("host"),
 			"Missing Todo API Host (using default value: 127.0.0.1)",
 			"The provider is using a default value as there is a missing or empty value for the Todo API host. "+
 				"Set the host value in the configuration or use the TODO_HOST environment variable. "+
 				"If either is already set, ensure the value is not empty.",
 		)
-		host = "127.0.0.1"
+		s.host = "127.0.0.1"
 	}
 
-	if port == "" {
-		resp.Diagnostics.AddAttributeWarning(
+	if s.port == "" {
+		diags.AddAttributeWarning(
 			path.Root("port"),
 			"Missing Todo API port (using default value: '8080')",
 			"The provider is using a default value as there is a missing or empty value for the Todo API port. "+
 				"Set the port value in the configuration or use the TODO_PORT environment variable. "+
 				"If either is already set, ensure the value is not empty.",
 		)
-		port = "8080"
+		s.port = "8080"
 	}
 
-	if schema == "" {
-		resp.Diagnostics.AddAttributeWarning(
+	if s.schema == "" {
+		diags.AddAttributeWarning(
 			path.Root("schema"),
 			"Missing Todo API Schema (using default value: http)",
 			"The provider is using a default value as there is a missing or empty value for the Todo API schema. "+
 				"Set the schema value in the configuration or use the TODO_SCHEMA environment variable. "+
 				"If either is already set, ensure the value is not empty.",
 		)
-		schema = "http"
+		s.schema = "http"
 	}
 
-	if apipath == "" {
-		resp.Diagnostics.AddAttributeWarning(
+	if s.apipath == "" {
+		diags.AddAttributeWarning(
 			path.Root("apipath"),
 			"Missing Todo API Path (using default value: /)",
 			"The provider is using a default value as there is a missing or empty value for the Todo API path. "+
 				"Set the apipath value in the configuration or use the TODO_APIPATH environment variable. "+
 				"If either is already set, ensure the value is not empty.",
 		)
-		apipath = "/"
+		s.apipath = "/"
 	}
+	return diags
+}
 
-	if resp.Diagnostics.HasError() {
-		return
+// validate checks the settings that have a fixed set of values, filling in
+// their defaults.
+func (s *todoProviderSettings) validate() diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	switch s.idReuseAction {
+	case "":
+		s.idReuseAction = idReuseActionError
+	case idReuseActionRemove, idReuseActionError:
+	default:
+		diags.AddAttributeError(
+			path.Root("id_reuse_action"),
+			"Invalid Todo ID Reuse Action",
+			"The ID reuse action must be either '"+idReuseActionRemove+"' or '"+idReuseActionError+"', got: '"+s.idReuseAction+"'. "+
+				"Check the id_reuse_action value in the configuration or the TODO_ID_REUSE_ACTION environment variable.",
+		)
+	}
+
+	switch s.descriptionNormalization {
+	case "":
+		s.descriptionNormalization = descriptionNormalizationExact
+	case descriptionNormalizationExact, descriptionNormalizationUnicode, descriptionNormalizationCaseInsensitive:
+	default:
+		diags.AddAttributeError(
+			path.Root("description_normalization"),
+			"Invalid Todo Description Normalization",
+			"The description normalization must be one of '"+descriptionNormalizationExact+"', '"+descriptionNormalizationUnicode+"' or '"+
+				descriptionNormalizationCaseInsensitive+"', got: '"+s.descriptionNormalization+"'. "+
+				"Check the description_normalization value in the configuration or the TODO_DESCRIPTION_NORMALIZATION environment variable.",
+		)
 	}
+	return diags
+}
 
-	ctx = tflog.SetField(ctx, "todo_host", host)
-	ctx = tflog.SetField(ctx, "todo_port", port)
-	ctx = tflog.SetField(ctx, "todo_schema", schema)
-	ctx = tflog.SetField(ctx, "todo_apipath", apipath)
+// newTodoProviderData creates the Todo client and everything else data
+// sources and resources need from the settings, and probes the server.
+func newTodoProviderData(ctx context.Context, settings todoProviderSettings) (*todoProviderData, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	consistencyWait := defaultConsistencyTimeout
+	if settings.consistencyTimeout != "" {
+		var err error
+		consistencyWait, err = time.ParseDuration(settings.consistencyTimeout)
+		if err == nil && consistencyWait < 0 {
+			err = errors.New("duration must not be negative")
+		}
+		if err != nil {
+			diags.AddAttributeError(
+				path.Root("consistency_timeout"),
+				"Invalid Todo Consistency Timeout",
+				"The consistency timeout must be a duration such as '30s' or '0s', got: '"+settings.consistencyTimeout+"'. "+
+					"Check the consistency_timeout value in the configuration or the TODO_CONSISTENCY_TIMEOUT environment variable.\n\n"+
+					"Duration Error: "+err.Error(),
+			)
+		}
+	}
+
+	keyring, err := newTodoKeyring(settings.encryptionKey, settings.previousEncryptionKeys)
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root("encryption_key"),
+			"Invalid Todo Encryption Keys",
+			"The provider cannot use the configured encryption keys. "+
+				"Check the encryption_key and previous_encryption_keys values in the configuration or the TODO_ENCRYPTION_KEY and TODO_PREVIOUS_ENCRYPTION_KEYS environment variables.\n\n"+
+				"Encryption Key Error: "+err.Error(),
+		)
+	}
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	ctx = tflog.SetField(ctx, "todo_host", settings.host)
+	ctx = tflog.SetField(ctx, "todo_port", settings.port)
+	ctx = tflog.SetField(ctx, "todo_schema", settings.schema)
+	ctx = tflog.SetField(ctx, "todo_apipath", settings.apipath)
+	ctx = tflog.SetField(ctx, "todo_id_reuse_action", settings.idReuseAction)
+	ctx = tflog.SetField(ctx, "todo_description_normalization", settings.descriptionNormalization)
+	ctx = tflog.SetField(ctx, "todo_encryption_enabled", keyring != nil)
+	ctx = tflog.SetField(ctx, "todo_consistency_timeout", consistencyWait.String())
 	// If we had a sensitive field we could mask it with something like this:
 	// ctx = tflog.MaskFieldValuesWithFieldKeys(ctx, "todo_password")
 
 	tflog.Debug(ctx, "Creating Todo client")
 
 	// Create a new Todo client using the configuration values
-	hostport := host + ":" + port
-	transport := httptransport.New(hostport, apipath, []string{schema})
-	transport.Consumers["application/spkane.todo-list.v1+json"] = runtime.JSONConsumer()
-	transport.Producers["application/spkane.todo-list.v1+json"] = runtime.JSONProducer()
-	// Instantiate the client that we will use to talk to the Todo server
-	client := client.New(transport, strfmt.Default)
-	params := todos.NewFindTodosParams()
-	var limit int32 = 1
-	params.SetLimit(&limit)
-	// Let's make sure we can talk to the server now
-	_, err := client.Todos.FindTodos(params)
-	if err != nil {
-		resp.Diagnostics.AddError(
+	client, endpoint := newTodoClient(settings.host, settings.port, settings.schema, settings.apipath)
+	// Let's make sure we can talk to the server now, keeping what we learn
+	// about it for the todo_server data source
+	probe := probeTodoServer(ctx, client, endpoint)
+	if probe.err != nil {
+		diags.AddError(
 			"Unable to Create Todo API Client",
 			"An unexpected error occurred when creating the Todo API client. "+
 				"If the error is not clear, please contact the provider developers.\n\n"+
-				"Todo Client Error: "+err.Error(),
+				"Todo Client Error: "+probe.err.Error(),
 		)
-		return
+		return nil, diags
 	}
-
-	// Make the Todo client available during DataSource and Resource
-	// type Configure methods.
-	resp.DataSourceData = client
-	resp.ResourceData = client
-
-	tflog.Info(ctx, "Configured Todo client", map[string]any{"success": true})
+	tflog.Debug(ctx, "Probed Todo server", map[string]any{
+		"endpoint":   probe.endpoint,
+		"latency":    probe.latency.String(),
+		"media_type": probe.mediaType})
+
+	return &todoProviderData{
+		client:                client,
+		idReuseAction:         settings.idReuseAction,
+		keyring:               keyring,
+		consistencyTimeout:    consistencyWait,
+		serverProbe:           probe,
+		descriptionNormalizer: &descriptionNormalizer{mode: settings.descriptionNormalization},
+	}, diags
 }
 
 // DataSources defines the data sources implemented in the provider.
 func (p *todoProvider) DataSources(_ context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
 		NewTodoDataSource,
+		NewTodoOrphansDataSource,
+		NewTodoTodosDataSource,
+		NewTodoStatsDataSource,
+		NewTodoServerDataSource,
+		NewTodoSearchDataSource,
+		NewTodoExportDataSource,
+		NewTodoICalendarDataSource,
+		NewTodoRevisionsDataSource,
 	}
 }
 
 // Resources defines the resources implemented in the provider.
 func (p *todoProvider) Resources(_ context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
-		NewTodoResource,
+		func() resource.Resource {
+			return NewTodoResource(p.descriptionNormalizers)
+		},
+		NewTodoOrphanCleanupResource,
+		NewTodoMarkdownSyncResource,
+		NewTodoICalendarImportResource,
+		NewTodoTodosResource,
+		NewTodoChecklistResource,
+		NewTodoCompletionResource,
+		NewTodoExclusiveResource,
+		NewTodoBulkOperationResource,
+		NewTodoRecurringResource,
+		NewTodoLockResource,
+		NewTodoSnapshotResource,
+		NewTodoRestoreResource,
+		NewTodoMirrorResource,
 	}
 }
+
+// newTodoClient instantiates a client for the Todo server at the given
+// address, and returns it with the endpoint URL of the server.
+func newTodoClient(host, port, schema, apipath string) (*client.TodoList, string) {
+	hostport := host + ":" + port
+	transport := httptransport.New(hostport, apipath, []string{schema})
+	transport.Consumers["application/spkane.todo-list.v1+json"] = runtime.JSONConsumer()
+	transport.Producers["application/spkane.todo-list.v1+json"] = runtime.JSONProducer()
+	return client.New(transport, strfmt.Default), schema + "://" + hostport + apipath
+}
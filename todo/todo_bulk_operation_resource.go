@@ -0,0 +1,435 @@
+package todo
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	// bulkActionComplete completes the matching todos.
+	bulkActionComplete = "complete"
+	// bulkActionReopen reopens the matching todos.
+	bulkActionReopen = "reopen"
+	// bulkActionDelete deletes the matching todos.
+	bulkActionDelete = "delete"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                   = &todoBulkOperationResource{}
+	_ resource.ResourceWithConfigure      = &todoBulkOperationResource{}
+	_ resource.ResourceWithValidateConfig = &todoBulkOperationResource{}
+	_ resource.ResourceWithModifyPlan     = &todoBulkOperationResource{}
+)
+
+// bulkOperationResultType is the object type of an entry in the results
+// attribute.
+var bulkOperationResultType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"id":        types.Int64Type,
+		"succeeded": types.BoolType,
+		"skipped":   types.BoolType,
+		"error":     types.StringType,
+	},
+}
+
+// NewTodoBulkOperationResource is a helper function to simplify the provider implementation.
+func NewTodoBulkOperationResource() resource.Resource {
+	return &todoBulkOperationResource{}
+}
+
+// todoBulkOperationResource is the resource implementation.
+type todoBulkOperationResource struct {
+	writer *todoWriter
+}
+
+// todoBulkOperationResourceModel maps the resource schema data.
+type todoBulkOperationResourceModel struct {
+	ID          types.String `tfsdk:"id"`
+	Filter      types.Object `tfsdk:"filter"`
+	Action      types.String `tfsdk:"action"`
+	MaxResults  types.Int64  `tfsdk:"max_results"`
+	Parallelism types.Int64  `tfsdk:"parallelism"`
+	Triggers    types.Map    `tfsdk:"triggers"`
+	AffectedIDs types.List   `tfsdk:"affected_ids"`
+	Results     types.List   `tfsdk:"results"`
+}
+
+// bulkOperationResultModel maps an entry in the results attribute.
+type bulkOperationResultModel struct {
+	ID        types.Int64  `tfsdk:"id"`
+	Succeeded types.Bool   `tfsdk:"succeeded"`
+	Skipped   types.Bool   `tfsdk:"skipped"`
+	Error     types.String `tfsdk:"error"`
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *todoBulkOperationResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	r.writer = newTodoWriter(req.ProviderData.(*todoProviderData))
+}
+
+// Metadata returns the resource type name.
+func (r *todoBulkOperationResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_bulk_operation"
+}
+
+// Schema defines the schema for the resource.
+func (r *todoBulkOperationResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Complete, reopen or delete every todo matching a filter. The plan lists the todos that will be affected, " +
+			"and the operation runs when the resource is created and again whenever it is replaced. Destroying the resource changes nothing.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The time the operation ran, in RFC 3339 format.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"filter": todoFilterResourceAttribute(),
+			"action": schema.StringAttribute{
+				Description: "What to do with the matching todos: `complete`, `reopen` or `delete`. Completing and reopening " +
+					"only change the completed status.",
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"max_results": schema.Int64Attribute{
+				Description: "Fail instead of affecting more than this many todos (default: 1000).",
+				Optional:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"parallelism": schema.Int64Attribute{
+				Description: "How many todos to change at a time (default: 10).",
+				Optional:    true,
+			},
+			"triggers": schema.MapAttribute{
+				Description: "Arbitrary values that run the operation again when they change.",
+				Optional:    true,
+				ElementType: types.StringType,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"affected_ids": schema.ListAttribute{
+				Description: "The unique identifiers of the matching todos, in ascending order.",
+				Computed:    true,
+				ElementType: types.Int64Type,
+			},
+			"results": schema.ListNestedAttribute{
+				Description: "The result of the action for each affected todo, in ascending ID order. Todos are matched against " +
+					"the filter again when the operation runs, and the planned ones that no longer match are skipped.",
+				Computed: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.Int64Attribute{
+							Description: "The unique identifier of the todo.",
+							Computed:    true,
+						},
+						"succeeded": schema.BoolAttribute{
+							Description: "Whether the action succeeded.",
+							Computed:    true,
+						},
+						"skipped": schema.BoolAttribute{
+							Description: "Whether the todo was left alone because it no longer matched the filter when the operation ran.",
+							Computed:    true,
+						},
+						"error": schema.StringAttribute{
+							Description: "Why the action failed, if it did.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// ValidateConfig checks the action and parallelism.
+func (r *todoBulkOperationResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config todoBulkOperationResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !config.Action.IsUnknown() {
+		switch action := config.Action.ValueString(); action {
+		case bulkActionComplete, bulkActionReopen, bulkActionDelete:
+		default:
+			resp.Diagnostics.AddAttributeError(
+				path.Root("action"),
+				"Invalid Bulk Action",
+				"The action must be one of "+bulkActionComplete+", "+bulkActionReopen+" or "+bulkActionDelete+", got: "+action,
+			)
+		}
+	}
+	if !config.Parallelism.IsUnknown() && !config.Parallelism.IsNull() && config.Parallelism.ValueInt64() < 1 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("parallelism"),
+			"Invalid Parallelism",
+			"The parallelism must be at least 1.",
+		)
+	}
+}
+
+// ModifyPlan lists the todos the operation will affect with FindTodos. An
+// operation that already ran keeps its results; Terraform plans a
+// replacement again as a create.
+func (r *todoBulkOperationResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to plan when the resource is being destroyed
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan todoBulkOperationResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Only the parallelism can change without running the operation again
+	if !req.State.Raw.IsNull() {
+		var state todoBulkOperationResourceModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		plan.AffectedIDs = state.AffectedIDs
+		plan.Results = state.Results
+		resp.Diagnostics.Append(resp.Plan.Set(ctx, plan)...)
+		return
+	}
+
+	if r.writer == nil || plan.Filter.IsUnknown() || plan.MaxResults.IsUnknown() {
+		return
+	}
+	ids, known, diags := r.findAffected(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || !known {
+		return
+	}
+
+	plan.AffectedIDs, diags = types.ListValueFrom(ctx, types.Int64Type, ids)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, plan)...)
+}
+
+// Create runs the action on the affected todos.
+func (r *todoBulkOperationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	tflog.Debug(ctx, "Preparing to create todo bulk operation resource")
+	// Retrieve values from plan
+	var plan todoBulkOperationResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Match the todos again, so a stale plan or a reused ID never affects a
+	// todo that no longer matches the filter
+	matching, known, diags := r.findAffected(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if !known && !resp.Diagnostics.HasError() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("filter"),
+			"Unknown Bulk Operation Filter",
+			"The filter is not known, so the affected todos cannot be found.",
+		)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Act on the todos listed in the plan, or on the matching ones if they
+	// were not known when planning
+	var ids []int64
+	if plan.AffectedIDs.IsUnknown() {
+		ids = matching
+		plan.AffectedIDs, diags = types.ListValueFrom(ctx, types.Int64Type, ids)
+		resp.Diagnostics.Append(diags...)
+	} else {
+		resp.Diagnostics.Append(plan.AffectedIDs.ElementsAs(ctx, &ids, false)...)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	matches := make(map[int64]bool, len(matching))
+	for _, id := range matching {
+		matches[id] = true
+	}
+
+	results := r.run(ctx, plan.Action.ValueString(), ids, matches, todosParallelism(plan.Parallelism))
+
+	plan.ID = types.StringValue(time.Now().UTC().Format(time.RFC3339))
+	plan.Results, diags = types.ListValueFrom(ctx, bulkOperationResultType, results)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var failures []string
+	for _, result := range results {
+		if !result.Succeeded.ValueBool() && !result.Skipped.ValueBool() {
+			failures = append(failures, result.ID.String()+": "+result.Error.ValueString())
+		}
+	}
+	if len(failures) > 0 {
+		resp.Diagnostics.AddError(
+			"Error Running Bulk Operation",
+			"Could not "+plan.Action.ValueString()+" some todos:\n\n"+strings.Join(failures, "\n"),
+		)
+		return
+	}
+	tflog.Debug(ctx, "Created todo bulk operation resource", map[string]any{"affected": len(results)})
+}
+
+// Read keeps the results of the operation, which are not stored on the
+// server.
+func (r *todoBulkOperationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state todoBulkOperationResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update only records a new parallelism, as every other configurable
+// attribute requires replacement.
+func (r *todoBulkOperationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan todoBulkOperationResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete removes the operation from state without touching the server.
+func (r *todoBulkOperationResource) Delete(ctx context.Context, _ resource.DeleteRequest, _ *resource.DeleteResponse) {
+	tflog.Debug(ctx, "Deleted todo bulk operation resource", map[string]any{"success": true})
+}
+
+// findAffected returns the IDs of the todos matching the filter of plan, in
+// ascending order, and whether the filter is known.
+func (r *todoBulkOperationResource) findAffected(ctx context.Context, plan todoBulkOperationResourceModel) ([]int64, bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	var model *todoFilterModel
+	if !plan.Filter.IsNull() {
+		model = &todoFilterModel{}
+		diags.Append(plan.Filter.As(ctx, model, basetypes.ObjectAsOptions{})...)
+		if diags.HasError() || model.isUnknown() {
+			return nil, false, diags
+		}
+	}
+
+	filter, filterDiags := newTodoFilter(model, path.Root("filter"))
+	diags.Append(filterDiags...)
+	maxResults, maxDiags := parseMaxResults(plan.MaxResults)
+	diags.Append(maxDiags...)
+	if diags.HasError() {
+		return nil, true, diags
+	}
+
+	todos, listDiags := listTodos(ctx, r.writer.client, r.writer.keyring, filter, maxResults)
+	diags.Append(listDiags...)
+	if diags.HasError() {
+		return nil, true, diags
+	}
+
+	ids := make([]int64, 0, len(todos))
+	for _, todo := range todos {
+		ids = append(ids, todo.ID)
+	}
+	return ids, true, diags
+}
+
+// run applies an action to the todos that still match the filter with at
+// most parallelism todos changed at a time, and returns the result for each
+// todo in the order of ids. Todos missing from matches are skipped.
+func (r *todoBulkOperationResource) run(ctx context.Context, action string, ids []int64, matches map[int64]bool, parallelism int) []bulkOperationResultModel {
+	errs := make([]error, len(ids))
+	forEachParallel(len(ids), parallelism, func(i int) {
+		if !matches[ids[i]] {
+			return
+		}
+		var previous *bool
+		switch action {
+		case bulkActionComplete:
+			previous, errs[i] = r.writer.setCompleted(ctx, ids[i], true)
+		case bulkActionReopen:
+			previous, errs[i] = r.writer.setCompleted(ctx, ids[i], false)
+		case bulkActionDelete:
+			errs[i] = r.writer.delete(ids[i])
+			return
+		}
+		if previous == nil && errs[i] == nil {
+			errs[i] = errors.New("todo not found")
+		}
+	})
+
+	results := make([]bulkOperationResultModel, 0, len(ids))
+	skipped := 0
+	for i, id := range ids {
+		result := bulkOperationResultModel{
+			ID:        types.Int64Value(id),
+			Succeeded: types.BoolValue(errs[i] == nil),
+			Skipped:   types.BoolValue(false),
+			Error:     types.StringNull(),
+		}
+		if !matches[id] {
+			result.Succeeded = types.BoolValue(false)
+			result.Skipped = types.BoolValue(true)
+			result.Error = types.StringValue("the todo no longer matches the filter")
+			tflog.Warn(ctx, "Skipped todo that no longer matches the bulk operation filter", map[string]any{"ID": id, "action": action})
+			skipped++
+		} else if errs[i] != nil {
+			result.Error = types.StringValue(errs[i].Error())
+			tflog.Warn(ctx, "Bulk operation failed for todo", map[string]any{"ID": id, "action": action, "Error": errs[i].Error()})
+		}
+		results = append(results, result)
+	}
+	tflog.Info(ctx, "Ran bulk operation", map[string]any{"action": action, "count": len(ids), "skipped": skipped})
+	return results
+}
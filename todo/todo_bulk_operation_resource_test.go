@@ -0,0 +1,118 @@
+package todo
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccTodoBulkOperationResource(t *testing.T) {
+	todos := `
+resource "todo_todo" "first" {
+	description = "[acc-bulk-operation] First"
+	completed   = false
+
+	lifecycle {
+		ignore_changes = [completed]
+	}
+}
+
+resource "todo_todo" "second" {
+	description = "[acc-bulk-operation] Second"
+	completed   = false
+
+	lifecycle {
+		ignore_changes = [completed]
+	}
+}
+`
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create the todos before the operation, so the plan can list them
+			{
+				Config: providerConfig + todos,
+			},
+			// Create and Read testing
+			{
+				Config: providerConfig + todos + `
+resource "todo_bulk_operation" "test" {
+	filter = {
+		description_prefix = "[acc-bulk-operation]"
+	}
+	action = "complete"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("todo_bulk_operation.test", "affected_ids.#", "2"),
+					resource.TestCheckResourceAttr("todo_bulk_operation.test", "results.#", "2"),
+					resource.TestCheckResourceAttr("todo_bulk_operation.test", "results.0.succeeded", "true"),
+					resource.TestCheckResourceAttr("todo_bulk_operation.test", "results.1.succeeded", "true"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestTodoBulkOperationRun(t *testing.T) {
+	const stored = "todo-enc:v1:wrapped:sealed"
+
+	r := &todoBulkOperationResource{writer: &todoWriter{client: newTestTodoClient(t, func(rw http.ResponseWriter, r *http.Request) {
+		rw.Header().Set("Content-Type", "application/spkane.todo-list.v1+json")
+		if strings.HasSuffix(r.URL.Path, "/2") {
+			rw.WriteHeader(http.StatusInternalServerError)
+			fmt.Fprint(rw, `{"code": 500, "message": "not found: item 2"}`)
+			return
+		}
+		if r.Method == http.MethodDelete {
+			rw.WriteHeader(http.StatusNoContent)
+			return
+		}
+		fmt.Fprintf(rw, `[{"id": 1, "description": %q, "completed": true}]`, stored)
+	})}}
+
+	results := r.run(context.Background(), bulkActionComplete, []int64{1, 2}, map[int64]bool{1: true, 2: true}, 2)
+	if len(results) != 2 {
+		t.Fatalf("expected 2 results, got %d", len(results))
+	}
+	if results[0].ID.ValueInt64() != 1 || !results[0].Succeeded.ValueBool() || !results[0].Error.IsNull() {
+		t.Errorf("expected completing todo 1 to succeed, got %+v", results[0])
+	}
+	if results[1].ID.ValueInt64() != 2 || results[1].Succeeded.ValueBool() || results[1].Error.IsNull() {
+		t.Errorf("expected completing missing todo 2 to fail, got %+v", results[1])
+	}
+
+	// Deleting a todo that is already gone succeeds
+	for _, result := range r.run(context.Background(), bulkActionDelete, []int64{1, 2}, map[int64]bool{1: true, 2: true}, 1) {
+		if !result.Succeeded.ValueBool() {
+			t.Errorf("expected deleting todo %d to succeed, got %+v", result.ID.ValueInt64(), result)
+		}
+	}
+}
+
+func TestTodoBulkOperationRunSkipsTodosNoLongerMatching(t *testing.T) {
+	var deleted []string
+	r := &todoBulkOperationResource{writer: &todoWriter{client: newTestTodoClient(t, func(rw http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodDelete {
+			deleted = append(deleted, r.URL.Path)
+		}
+		rw.WriteHeader(http.StatusNoContent)
+	})}}
+
+	// Todo 2 was planned, but its ID now belongs to a todo outside the filter
+	results := r.run(context.Background(), bulkActionDelete, []int64{1, 2}, map[int64]bool{1: true}, 1)
+	if len(deleted) != 1 || deleted[0] != "/1" {
+		t.Errorf("expected only todo 1 to be deleted, got %v", deleted)
+	}
+	if len(results) != 2 || !results[0].Succeeded.ValueBool() || results[0].Skipped.ValueBool() {
+		t.Fatalf("expected deleting todo 1 to succeed, got %+v", results)
+	}
+	if results[1].Succeeded.ValueBool() || !results[1].Skipped.ValueBool() || results[1].Error.IsNull() {
+		t.Errorf("expected todo 2 to be skipped, got %+v", results[1])
+	}
+}
@@ -0,0 +1,639 @@
+package todo
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                   = &todoChecklistResource{}
+	_ resource.ResourceWithConfigure      = &todoChecklistResource{}
+	_ resource.ResourceWithValidateConfig = &todoChecklistResource{}
+	_ resource.ResourceWithModifyPlan     = &todoChecklistResource{}
+)
+
+// checklistTodoType is the object type of an entry in the todos attribute.
+var checklistTodoType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"step":        types.StringType,
+		"id":          types.Int64Type,
+		"description": types.StringType,
+		"completed":   types.BoolType,
+	},
+}
+
+// NewTodoChecklistResource is a helper function to simplify the provider implementation.
+func NewTodoChecklistResource() resource.Resource {
+	return &todoChecklistResource{}
+}
+
+// todoChecklistResource is the resource implementation.
+type todoChecklistResource struct {
+	writer *todoWriter
+}
+
+// todoChecklistResourceModel maps the resource schema data.
+type todoChecklistResourceModel struct {
+	ID              types.String `tfsdk:"id"`
+	Title           types.String `tfsdk:"title"`
+	Steps           types.List   `tfsdk:"steps"`
+	CreateParent    types.Bool   `tfsdk:"create_parent"`
+	ParentID        types.Int64  `tfsdk:"parent_id"`
+	ParentCompleted types.Bool   `tfsdk:"parent_completed"`
+	Todos           types.List   `tfsdk:"todos"`
+	ProgressPercent types.Int64  `tfsdk:"progress_percent"`
+	NextStep        types.String `tfsdk:"next_step"`
+	AllDone         types.Bool   `tfsdk:"all_done"`
+}
+
+// checklistTodoModel maps an entry in the todos attribute.
+type checklistTodoModel struct {
+	Step        types.String `tfsdk:"step"`
+	ID          types.Int64  `tfsdk:"id"`
+	Description types.String `tfsdk:"description"`
+	Completed   types.Bool   `tfsdk:"completed"`
+}
+
+// checklistStepDescription returns the description of the todo of a step.
+func checklistStepDescription(title, step string) string {
+	return title + ": " + step
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *todoChecklistResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	r.writer = newTodoWriter(req.ProviderData.(*todoProviderData))
+}
+
+// Metadata returns the resource type name.
+func (r *todoChecklistResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_checklist"
+}
+
+// Schema defines the schema for the resource.
+func (r *todoChecklistResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manage a checklist: an ordered list of steps, each with a todo described as `<title>: <step>`. " +
+			"Steps are checked off by completing their todos outside of Terraform, and the progress is read back on refresh.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "A unique identifier for the checklist.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"title": schema.StringAttribute{
+				Description: "The title of the checklist, which prefixes the description of every step todo.",
+				Required:    true,
+			},
+			"steps": schema.ListAttribute{
+				Description: "The steps of the checklist, in order. Steps are matched to their todos by text, so reordering steps " +
+					"keeps their todos, while adding or removing a step creates or deletes one.",
+				Required:    true,
+				ElementType: types.StringType,
+			},
+			"create_parent": schema.BoolAttribute{
+				Description: "Also create a parent todo described by the title. It is completed by the first apply after every step is, " +
+					"and reopened when a step is added to a finished checklist (default: false).",
+				Optional: true,
+			},
+			"parent_id": schema.Int64Attribute{
+				Description: "The unique identifier of the parent todo, if create_parent is set.",
+				Computed:    true,
+			},
+			"parent_completed": schema.BoolAttribute{
+				Description: "Whether the parent todo is completed, if create_parent is set.",
+				Computed:    true,
+			},
+			"todos": schema.ListNestedAttribute{
+				Description: "The step todos, in step order.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"step": schema.StringAttribute{
+							Description: "The step.",
+							Computed:    true,
+						},
+						"id": schema.Int64Attribute{
+							Description: "The unique identifier of the step's todo.",
+							Computed:    true,
+						},
+						"description": schema.StringAttribute{
+							Description: "The description of the step's todo.",
+							Computed:    true,
+						},
+						"completed": schema.BoolAttribute{
+							Description: "Whether the step's todo is completed.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"progress_percent": schema.Int64Attribute{
+				Description: "The percentage of completed steps, rounded down.",
+				Computed:    true,
+			},
+			"next_step": schema.StringAttribute{
+				Description: "The first step that is not completed, or null once every step is.",
+				Computed:    true,
+			},
+			"all_done": schema.BoolAttribute{
+				Description: "Whether every step is completed.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// ValidateConfig checks that there is at least one step and that every step
+// is listed once and not empty.
+func (r *todoChecklistResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var steps types.List
+
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("steps"), &steps)...)
+	if resp.Diagnostics.HasError() || steps.IsNull() || steps.IsUnknown() {
+		return
+	}
+
+	if len(steps.Elements()) == 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("steps"),
+			"Invalid Checklist Steps",
+			"A checklist needs at least one step.",
+		)
+		return
+	}
+
+	seen := make(map[string]bool, len(steps.Elements()))
+	for i, element := range steps.Elements() {
+		step, ok := element.(types.String)
+		if !ok || step.IsUnknown() {
+			continue
+		}
+		if step.ValueString() == "" {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("steps").AtListIndex(i),
+				"Invalid Checklist Step",
+				"A step must not be empty.",
+			)
+		}
+		if seen[step.ValueString()] {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("steps").AtListIndex(i),
+				"Duplicate Checklist Step",
+				"The step "+strconv.Quote(step.ValueString())+" is listed more than once.",
+			)
+		}
+		seen[step.ValueString()] = true
+	}
+}
+
+// ModifyPlan plans the step todos and progress, keeping the IDs and
+// completed status of steps that already have a todo, and plans the parent
+// todo to be completed exactly when every step is.
+func (r *todoChecklistResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to plan when the resource is being destroyed
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan todoChecklistResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var state todoChecklistResourceModel
+	existing := map[string]checklistTodoModel{}
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		todos, diags := checklistTodos(ctx, state.Todos)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		for _, todo := range todos {
+			existing[todo.Step.ValueString()] = todo
+		}
+	}
+
+	// Plan the parent todo
+	switch {
+	case !plan.CreateParent.ValueBool():
+		plan.ParentID = types.Int64Null()
+		plan.ParentCompleted = types.BoolNull()
+	case !req.State.Raw.IsNull() && !state.ParentID.IsNull():
+		plan.ParentID = state.ParentID
+		plan.ParentCompleted = types.BoolUnknown()
+	default:
+		plan.ParentID = types.Int64Unknown()
+		plan.ParentCompleted = types.BoolUnknown()
+	}
+
+	if plan.Title.IsUnknown() || plan.Steps.IsUnknown() {
+		resp.Diagnostics.Append(resp.Plan.Set(ctx, plan)...)
+		return
+	}
+	steps, diags := checklistSteps(ctx, plan.Steps)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if steps == nil {
+		resp.Diagnostics.Append(resp.Plan.Set(ctx, plan)...)
+		return
+	}
+
+	todos := make([]checklistTodoModel, 0, len(steps))
+	for _, step := range steps {
+		todo := checklistTodoModel{
+			Step:        types.StringValue(step),
+			ID:          types.Int64Unknown(),
+			Description: types.StringValue(checklistStepDescription(plan.Title.ValueString(), step)),
+			Completed:   types.BoolValue(false),
+		}
+		if current, ok := existing[step]; ok {
+			todo.ID = current.ID
+			todo.Completed = current.Completed
+		}
+		todos = append(todos, todo)
+	}
+
+	resp.Diagnostics.Append(plan.setTodos(ctx, todos)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if plan.CreateParent.ValueBool() {
+		plan.ParentCompleted = plan.AllDone
+	}
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, plan)...)
+}
+
+// Create creates a todo for every step and, if requested, the parent todo.
+func (r *todoChecklistResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	tflog.Debug(ctx, "Preparing to create todo checklist resource")
+	// Retrieve values from plan
+	var plan todoChecklistResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id, err := uuid.GenerateUUID()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Creating Checklist",
+			"Could not generate an ID, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	plan.ID = types.StringValue(id)
+	plan.ParentID = types.Int64Null()
+	plan.ParentCompleted = types.BoolNull()
+
+	resp.Diagnostics.Append(r.sync(ctx, &plan, nil)...)
+
+	// Save the todos that were created even if others failed
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+	tflog.Debug(ctx, "Created todo checklist resource", map[string]any{"success": !resp.Diagnostics.HasError()})
+}
+
+// Read refreshes the step todos and the parent todo with FindTodo. A parent
+// todo left open once every step is completed is completed by the next
+// apply, as planned by ModifyPlan.
+func (r *todoChecklistResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	tflog.Debug(ctx, "Preparing to read todo checklist resource")
+	// Get current state
+	var state todoChecklistResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	todos, diags := checklistTodos(ctx, state.Todos)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	entries, err := r.writer.refresh(ctx, checklistEntries(todos))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Todos",
+			"Could not read checklist todos: "+err.Error(),
+		)
+		return
+	}
+	resp.Diagnostics.Append(state.setTodos(ctx, checklistTodosFromEntries(entries))...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !state.ParentID.IsNull() {
+		parent, err := r.writer.read(state.ParentID.ValueInt64())
+		switch {
+		case err != nil:
+			resp.Diagnostics.AddError(
+				"Error Reading Todo",
+				"Could not read parent todo ID "+state.ParentID.String()+": "+err.Error(),
+			)
+			return
+		case parent == nil:
+			// Planned to be created again
+			state.ParentID = types.Int64Null()
+			state.ParentCompleted = types.BoolNull()
+		default:
+			state.ParentCompleted = types.BoolValue(parent.Completed)
+		}
+	}
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	tflog.Debug(ctx, "Finished reading todo checklist resource", map[string]any{"success": true})
+}
+
+// Update creates, updates and deletes step todos to match the steps, and
+// creates, updates or deletes the parent todo.
+func (r *todoChecklistResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	tflog.Debug(ctx, "Preparing to update todo checklist resource")
+	// Retrieve values from plan
+	var plan todoChecklistResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Retrieve the todos that exist from state
+	var state todoChecklistResourceModel
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	existing, diags := checklistTodos(ctx, state.Todos)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	plan.ParentID = state.ParentID
+	plan.ParentCompleted = state.ParentCompleted
+
+	resp.Diagnostics.Append(r.sync(ctx, &plan, existing)...)
+
+	// Save the changes that were made even if others failed
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+	tflog.Debug(ctx, "Updated todo checklist resource", map[string]any{"success": !resp.Diagnostics.HasError()})
+}
+
+// Delete deletes the step todos and the parent todo.
+func (r *todoChecklistResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	tflog.Debug(ctx, "Preparing to delete todo checklist resource")
+	// Retrieve values from state
+	var state todoChecklistResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	todos, diags := checklistTodos(ctx, state.Todos)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ids := make([]types.Int64, 0, len(todos)+1)
+	for _, todo := range todos {
+		ids = append(ids, todo.ID)
+	}
+	if !state.ParentID.IsNull() {
+		ids = append(ids, state.ParentID)
+	}
+	for _, id := range ids {
+		if err := r.writer.delete(id.ValueInt64()); err != nil {
+			resp.Diagnostics.AddError(
+				"Error Deleting Todo",
+				"Could not delete todo ID "+id.String()+", unexpected error: "+err.Error(),
+			)
+		}
+	}
+	tflog.Debug(ctx, "Deleted todo checklist resource", map[string]any{"success": !resp.Diagnostics.HasError()})
+}
+
+// sync makes the step todos on the server match the steps in plan, given the
+// step todos that already exist, then brings the parent todo in line with
+// create_parent. It leaves plan with the todos that exist afterwards.
+func (r *todoChecklistResource) sync(ctx context.Context, plan *todoChecklistResourceModel, existing []checklistTodoModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	steps, stepDiags := checklistSteps(ctx, plan.Steps)
+	diags.Append(stepDiags...)
+	if diags.HasError() {
+		return diags
+	}
+
+	// Steps keep their completed status, which is only changed outside of
+	// Terraform
+	completed := make(map[string]bool, len(existing))
+	for _, todo := range existing {
+		completed[todo.Step.ValueString()] = todo.Completed.ValueBool()
+	}
+	title := plan.Title.ValueString()
+	targets := make([]todoSyncTarget, 0, len(steps))
+	for _, step := range steps {
+		targets = append(targets, todoSyncTarget{
+			Key:         step,
+			Description: checklistStepDescription(title, step),
+			Completed:   completed[step],
+		})
+	}
+
+	entries, syncDiags := r.writer.sync(ctx, targets, checklistEntries(existing), 1, false)
+	diags.Append(syncDiags...)
+	diags.Append(plan.setTodos(ctx, checklistTodosFromEntries(entries))...)
+	if diags.HasError() {
+		return diags
+	}
+
+	diags.Append(r.syncParent(ctx, plan)...)
+	return diags
+}
+
+// syncParent creates, updates or deletes the parent todo of plan, which is
+// described by the title and completed when every step is.
+func (r *todoChecklistResource) syncParent(ctx context.Context, plan *todoChecklistResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	title := plan.Title.ValueString()
+	allDone := plan.AllDone.ValueBool()
+
+	if !plan.CreateParent.ValueBool() {
+		if plan.ParentID.IsNull() {
+			return diags
+		}
+		if err := r.writer.delete(plan.ParentID.ValueInt64()); err != nil {
+			diags.AddError(
+				"Error Deleting Todo",
+				"Could not delete parent todo ID "+plan.ParentID.String()+", unexpected error: "+err.Error(),
+			)
+			return diags
+		}
+		plan.ParentID = types.Int64Null()
+		plan.ParentCompleted = types.BoolNull()
+		return diags
+	}
+
+	if !plan.ParentID.IsNull() {
+		parent, err := r.writer.read(plan.ParentID.ValueInt64())
+		if err != nil {
+			diags.AddError(
+				"Error Reading Todo",
+				"Could not read parent todo ID "+plan.ParentID.String()+": "+err.Error(),
+			)
+			return diags
+		}
+		if parent != nil {
+			if r.writer.descriptionNormalizer.equal(title, parent.Description) && parent.Completed == allDone {
+				plan.ParentCompleted = types.BoolValue(allDone)
+				return diags
+			}
+			if _, err := r.writer.update(ctx, parent.ID, title, allDone); err != nil {
+				diags.AddError(
+					"Error Updating Todo",
+					"Could not update parent todo ID "+plan.ParentID.String()+", unexpected error: "+err.Error(),
+				)
+				return diags
+			}
+			plan.ParentCompleted = types.BoolValue(allDone)
+			return diags
+		}
+	}
+
+	parent, err := r.writer.create(ctx, title, allDone)
+	if err != nil {
+		diags.AddError(
+			"Error creating todo",
+			"Could not create the parent todo, unexpected error: "+err.Error(),
+		)
+		plan.ParentID = types.Int64Null()
+		plan.ParentCompleted = types.BoolNull()
+		return diags
+	}
+	plan.ParentID = types.Int64Value(parent.ID)
+	plan.ParentCompleted = types.BoolValue(parent.Completed)
+	return diags
+}
+
+// checklistProgress returns the percentage of completed todos, rounded
+// down, and the step of the first todo that is not completed. Steps are
+// never empty, so the step is empty once every todo is completed.
+func checklistProgress(todos []checklistTodoModel) (int64, string) {
+	if len(todos) == 0 {
+		return 100, ""
+	}
+
+	done := 0
+	next := ""
+	for _, todo := range todos {
+		switch {
+		case todo.Completed.ValueBool():
+			done++
+		case next == "":
+			next = todo.Step.ValueString()
+		}
+	}
+	return int64(done * 100 / len(todos)), next
+}
+
+// checklistSteps returns the steps of the steps attribute. A null list has
+// no steps, and an unknown list or step returns nil steps.
+func checklistSteps(ctx context.Context, list types.List) ([]string, diag.Diagnostics) {
+	var steps []types.String
+	diags := list.ElementsAs(ctx, &steps, false)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	values := make([]string, 0, len(steps))
+	for _, step := range steps {
+		if step.IsUnknown() {
+			return nil, diags
+		}
+		values = append(values, step.ValueString())
+	}
+	return values, diags
+}
+
+// checklistEntries converts todos to sync entries keyed by step.
+func checklistEntries(todos []checklistTodoModel) []todoSyncEntry {
+	entries := make([]todoSyncEntry, 0, len(todos))
+	for _, todo := range todos {
+		entries = append(entries, todoSyncEntry{
+			Key:         todo.Step.ValueString(),
+			ID:          todo.ID.ValueInt64(),
+			Description: todo.Description.ValueString(),
+			Completed:   todo.Completed.ValueBool(),
+		})
+	}
+	return entries
+}
+
+// checklistTodosFromEntries converts sync entries to todos.
+func checklistTodosFromEntries(entries []todoSyncEntry) []checklistTodoModel {
+	todos := make([]checklistTodoModel, 0, len(entries))
+	for _, entry := range entries {
+		todos = append(todos, checklistTodoModel{
+			Step:        types.StringValue(entry.Key),
+			ID:          types.Int64Value(entry.ID),
+			Description: types.StringValue(entry.Description),
+			Completed:   types.BoolValue(entry.Completed),
+		})
+	}
+	return todos
+}
+
+// checklistTodos converts the todos attribute to its entries. Null and
+// unknown lists have no entries.
+func checklistTodos(ctx context.Context, list types.List) ([]checklistTodoModel, diag.Diagnostics) {
+	var todos []checklistTodoModel
+	if list.IsNull() || list.IsUnknown() {
+		return todos, nil
+	}
+	diags := list.ElementsAs(ctx, &todos, false)
+	return todos, diags
+}
+
+// setTodos sets the todos attribute and the progress computed from it.
+func (m *todoChecklistResourceModel) setTodos(ctx context.Context, todos []checklistTodoModel) diag.Diagnostics {
+	list, diags := types.ListValueFrom(ctx, checklistTodoType, todos)
+	m.Todos = list
+
+	percent, next := checklistProgress(todos)
+	m.ProgressPercent = types.Int64Value(percent)
+	m.NextStep = types.StringNull()
+	if next != "" {
+		m.NextStep = types.StringValue(next)
+	}
+	m.AllDone = types.BoolValue(next == "")
+	return diags
+}
@@ -0,0 +1,88 @@
+package todo
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccTodoChecklistResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: providerConfig + `
+resource "todo_checklist" "test" {
+	title         = "Release 1.2"
+	steps         = ["Tag the release", "Publish the binaries"]
+	create_parent = true
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("todo_checklist.test", "todos.#", "2"),
+					resource.TestCheckResourceAttr("todo_checklist.test", "todos.0.description", "Release 1.2: Tag the release"),
+					resource.TestCheckResourceAttr("todo_checklist.test", "todos.0.completed", "false"),
+					resource.TestCheckResourceAttr("todo_checklist.test", "progress_percent", "0"),
+					resource.TestCheckResourceAttr("todo_checklist.test", "next_step", "Tag the release"),
+					resource.TestCheckResourceAttr("todo_checklist.test", "all_done", "false"),
+					resource.TestCheckResourceAttrSet("todo_checklist.test", "parent_id"),
+					resource.TestCheckResourceAttr("todo_checklist.test", "parent_completed", "false"),
+				),
+			},
+			// Update and Read testing
+			{
+				Config: providerConfig + `
+resource "todo_checklist" "test" {
+	title = "Release 1.2"
+	steps = ["Publish the binaries", "Announce the release"]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("todo_checklist.test", "todos.#", "2"),
+					resource.TestCheckResourceAttr("todo_checklist.test", "todos.1.description", "Release 1.2: Announce the release"),
+					resource.TestCheckResourceAttr("todo_checklist.test", "next_step", "Publish the binaries"),
+					resource.TestCheckNoResourceAttr("todo_checklist.test", "parent_id"),
+					resource.TestCheckNoResourceAttr("todo_checklist.test", "parent_completed"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestChecklistProgress(t *testing.T) {
+	todo := func(step string, completed bool) checklistTodoModel {
+		return checklistTodoModel{Step: types.StringValue(step), Completed: types.BoolValue(completed)}
+	}
+
+	testCases := map[string]struct {
+		todos   []checklistTodoModel
+		percent int64
+		next    string
+	}{
+		"none done": {
+			todos:   []checklistTodoModel{todo("a", false), todo("b", false)},
+			percent: 0,
+			next:    "a",
+		},
+		"out of order": {
+			todos:   []checklistTodoModel{todo("a", true), todo("b", false), todo("c", true)},
+			percent: 66,
+			next:    "b",
+		},
+		"all done": {
+			todos:   []checklistTodoModel{todo("a", true), todo("b", true)},
+			percent: 100,
+			next:    "",
+		},
+	}
+
+	for name, tc := range testCases {
+		percent, next := checklistProgress(tc.todos)
+		if percent != tc.percent || next != tc.next {
+			t.Errorf("%s: expected %d%% and %q, got %d%% and %q", name, tc.percent, tc.next, percent, next)
+		}
+	}
+}
@@ -0,0 +1,242 @@
+package todo
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource               = &todoCompletionResource{}
+	_ resource.ResourceWithConfigure  = &todoCompletionResource{}
+	_ resource.ResourceWithModifyPlan = &todoCompletionResource{}
+)
+
+// NewTodoCompletionResource is a helper function to simplify the provider implementation.
+func NewTodoCompletionResource() resource.Resource {
+	return &todoCompletionResource{}
+}
+
+// todoCompletionResource is the resource implementation.
+type todoCompletionResource struct {
+	writer *todoWriter
+}
+
+// todoCompletionResourceModel maps the resource schema data.
+type todoCompletionResourceModel struct {
+	ID                types.String `tfsdk:"id"`
+	TodoID            types.Int64  `tfsdk:"todo_id"`
+	Completed         types.Bool   `tfsdk:"completed"`
+	PreviousCompleted types.Bool   `tfsdk:"previous_completed"`
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *todoCompletionResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	r.writer = newTodoWriter(req.ProviderData.(*todoProviderData))
+}
+
+// Metadata returns the resource type name.
+func (r *todoCompletionResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_completion"
+}
+
+// Schema defines the schema for the resource.
+func (r *todoCompletionResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Mark a todo that is managed elsewhere as completed, and restore its previous completed status on destroy. " +
+			"Only the completed status is changed; the description is written back exactly as it is stored.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "A unique identifier for the completion.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"todo_id": schema.Int64Attribute{
+				Description: "The unique identifier of the todo to complete.",
+				Required:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"completed": schema.BoolAttribute{
+				Description: "The completed status of the todo. A todo reopened outside of Terraform is completed again on the next apply.",
+				Computed:    true,
+			},
+			"previous_completed": schema.BoolAttribute{
+				Description: "The completed status the todo had before it was completed, which is restored on destroy.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+// ModifyPlan plans the todo to be completed, so a todo reopened outside of
+// Terraform is completed again.
+func (r *todoCompletionResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to plan when the resource is being destroyed
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("completed"), types.BoolValue(true))...)
+}
+
+// Create completes the todo, recording its previous completed status.
+func (r *todoCompletionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	tflog.Debug(ctx, "Preparing to create todo completion resource")
+	// Retrieve values from plan
+	var plan todoCompletionResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id, err := uuid.GenerateUUID()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Creating Completion",
+			"Could not generate an ID, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	previous, err := r.writer.setCompleted(ctx, plan.TodoID.ValueInt64(), true)
+	if previous == nil && err == nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("todo_id"),
+			"Todo Not Found",
+			"No todo with ID "+plan.TodoID.String()+" exists.",
+		)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Updating Todo",
+			"Could not complete todo ID "+plan.TodoID.String()+", unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	plan.ID = types.StringValue(id)
+	plan.Completed = types.BoolValue(true)
+	plan.PreviousCompleted = types.BoolValue(*previous)
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	tflog.Debug(ctx, "Created todo completion resource", map[string]any{"success": !resp.Diagnostics.HasError()})
+}
+
+// Read refreshes the completed status of the todo. The completion is
+// removed from state if the todo no longer exists.
+func (r *todoCompletionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	tflog.Debug(ctx, "Preparing to read todo completion resource")
+	// Get current state
+	var state todoCompletionResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	item, err := r.writer.readStored(state.TodoID.ValueInt64())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Todo",
+			"Could not read todo ID "+state.TodoID.String()+": "+err.Error(),
+		)
+		return
+	}
+	if item == nil {
+		tflog.Debug(ctx, "Completed todo no longer exists", map[string]any{"ID": state.TodoID.String()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	state.Completed = types.BoolValue(*item.Completed)
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	tflog.Debug(ctx, "Finished reading todo completion resource", map[string]any{"success": true})
+}
+
+// Update completes the todo again after it was reopened outside of
+// Terraform, keeping the recorded previous completed status.
+func (r *todoCompletionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	tflog.Debug(ctx, "Preparing to update todo completion resource")
+	// Retrieve values from plan
+	var plan todoCompletionResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	previous, err := r.writer.setCompleted(ctx, plan.TodoID.ValueInt64(), true)
+	if previous == nil && err == nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("todo_id"),
+			"Todo Not Found",
+			"No todo with ID "+plan.TodoID.String()+" exists.",
+		)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Updating Todo",
+			"Could not complete todo ID "+plan.TodoID.String()+", unexpected error: "+err.Error(),
+		)
+		return
+	}
+	plan.Completed = types.BoolValue(true)
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	tflog.Debug(ctx, "Updated todo completion resource", map[string]any{"success": !resp.Diagnostics.HasError()})
+}
+
+// Delete restores the completed status the todo had before it was
+// completed. A todo that no longer exists is left alone.
+func (r *todoCompletionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	tflog.Debug(ctx, "Preparing to delete todo completion resource")
+	// Retrieve values from state
+	var state todoCompletionResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	restored := state.PreviousCompleted.ValueBool()
+	if _, err := r.writer.setCompleted(ctx, state.TodoID.ValueInt64(), restored); err != nil {
+		resp.Diagnostics.AddError(
+			"Error Updating Todo",
+			"Could not restore todo ID "+state.TodoID.String()+" to completed = "+strconv.FormatBool(restored)+
+				", unexpected error: "+err.Error(),
+		)
+		return
+	}
+	tflog.Debug(ctx, "Deleted todo completion resource", map[string]any{"success": true})
+}
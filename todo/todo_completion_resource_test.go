@@ -0,0 +1,76 @@
+package todo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccTodoCompletionResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: providerConfig + `
+resource "todo_todo" "deploy" {
+	description = "Deploy v2"
+	completed   = false
+
+	lifecycle {
+		ignore_changes = [completed]
+	}
+}
+
+resource "todo_completion" "test" {
+	todo_id = todo_todo.deploy.id
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("todo_completion.test", "completed", "true"),
+					resource.TestCheckResourceAttr("todo_completion.test", "previous_completed", "false"),
+					resource.TestCheckResourceAttrSet("todo_completion.test", "id"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestTodoWriterSetCompleted(t *testing.T) {
+	const stored = "todo-enc:v1:wrapped:sealed"
+	var written map[string]any
+	completed := false
+
+	w := &todoWriter{client: newTestTodoClient(t, func(rw http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPut {
+			if err := json.NewDecoder(r.Body).Decode(&written); err != nil {
+				t.Errorf("unexpected body: %v", err)
+			}
+			completed = written["completed"].(bool)
+			rw.Header().Set("Content-Type", "application/spkane.todo-list.v1+json")
+			fmt.Fprintf(rw, `{"id": 1, "description": %q, "completed": %t}`, stored, completed)
+			return
+		}
+		rw.Header().Set("Content-Type", "application/spkane.todo-list.v1+json")
+		fmt.Fprintf(rw, `[{"id": 1, "description": %q, "completed": %t}]`, stored, completed)
+	})}
+
+	previous, err := w.setCompleted(context.Background(), 1, true)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if previous == nil || *previous {
+		t.Errorf("expected the previous status to be false, got %v", previous)
+	}
+	if written["description"] != stored {
+		t.Errorf("expected the stored description to be written back, got %v", written["description"])
+	}
+	if !completed {
+		t.Error("expected the todo to be completed")
+	}
+}
@@ -0,0 +1,81 @@
+package todo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	// Todo API Libraries
+	"github.com/spkane/todo-for-terraform/client"
+	"github.com/spkane/todo-for-terraform/client/todos"
+	"github.com/spkane/todo-for-terraform/models"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	// defaultConsistencyTimeout bounds how long writes are polled for.
+	defaultConsistencyTimeout = 10 * time.Second
+
+	consistencyMinInterval = 100 * time.Millisecond
+	consistencyMaxInterval = 2 * time.Second
+)
+
+// errTodoNotConsistent is returned when a todo never read back as written.
+var errTodoNotConsistent = errors.New("todo did not read back as written before the consistency timeout")
+
+// waitForTodo reads a todo back with FindTodo until match accepts it, backing
+// off between attempts. Caching proxies in front of the Todo server may serve
+// stale or missing items for a while after a write.
+//
+// With a timeout of zero the first successful read is returned as-is.
+func waitForTodo(ctx context.Context, c *client.TodoList, id int64, timeout time.Duration, match func(*models.Item) (bool, error)) (*models.Item, error) {
+	deadline := time.Now().Add(timeout)
+	interval := consistencyMinInterval
+
+	for attempt := 1; ; attempt++ {
+		params := todos.NewFindTodoParams()
+		params.SetID(id)
+		result, err := c.Todos.FindTodo(params)
+
+		var item *models.Item
+		if err == nil && len(result.GetPayload()) > 0 {
+			item = result.GetPayload()[0]
+			if timeout <= 0 {
+				return item, nil
+			}
+
+			var ok bool
+			ok, err = match(item)
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				return item, nil
+			}
+		}
+		if err == nil {
+			err = errTodoNotConsistent
+		}
+
+		if timeout <= 0 || time.Now().Add(interval).After(deadline) {
+			return nil, err
+		}
+
+		tflog.Debug(ctx, "Waiting for todo to read back as written", map[string]any{
+			"ID":      id,
+			"attempt": attempt,
+			"Error":   err.Error()})
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(interval):
+		}
+
+		interval *= 2
+		if interval > consistencyMaxInterval {
+			interval = consistencyMaxInterval
+		}
+	}
+}
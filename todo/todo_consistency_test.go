@@ -0,0 +1,163 @@
+package todo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	// Todo API Libraries
+	"github.com/spkane/todo-for-terraform/client"
+	"github.com/spkane/todo-for-terraform/models"
+)
+
+// newTestTodoClient returns a Todo client for an httptest server.
+func newTestTodoClient(t *testing.T, handler http.HandlerFunc) *client.TodoList {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	host, port, err := net.SplitHostPort(strings.TrimPrefix(server.URL, "http://"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	c, _ := newTodoClient(host, port, "http", "/")
+	return c
+}
+
+// fakeTodoServer is an in-memory Todo server for tests of code that
+// creates, updates and deletes todos.
+type fakeTodoServer struct {
+	mu     sync.Mutex
+	todos  map[int64]map[string]any
+	nextID int64
+	// onList, if set, is called with the lock held before todos are listed.
+	onList func(todos map[int64]map[string]any)
+}
+
+// newFakeTodoServer starts a fakeTodoServer holding todos, and returns it
+// with a client for it. New todos get IDs after the highest existing one.
+func newFakeTodoServer(t *testing.T, todos map[int64]map[string]any) (*fakeTodoServer, *client.TodoList) {
+	t.Helper()
+
+	server := &fakeTodoServer{todos: todos, nextID: 1}
+	for id := range todos {
+		if id >= server.nextID {
+			server.nextID = id + 1
+		}
+	}
+	return server, newTestTodoClient(t, server.serveHTTP)
+}
+
+// get returns a todo on the server, or nil if there is none with the ID.
+func (s *fakeTodoServer) get(id int64) map[string]any {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.todos[id]
+}
+
+func (s *fakeTodoServer) serveHTTP(rw http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var id int64
+	fmt.Sscanf(r.URL.Path, "/%d", &id)
+	var body map[string]any
+	if r.Body != nil {
+		encoded, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(encoded, &body)
+	}
+
+	rw.Header().Set("Content-Type", "application/spkane.todo-list.v1+json")
+	switch {
+	case r.Method == http.MethodGet && id != 0:
+		items := []map[string]any{}
+		if item, ok := s.todos[id]; ok {
+			items = append(items, item)
+		}
+		_ = json.NewEncoder(rw).Encode(items)
+	case r.Method == http.MethodGet:
+		if s.onList != nil {
+			s.onList(s.todos)
+		}
+		since, _ := strconv.ParseInt(r.URL.Query().Get("since"), 10, 64)
+		items := []map[string]any{}
+		for itemID, item := range s.todos {
+			if itemID > since {
+				items = append(items, item)
+			}
+		}
+		_ = json.NewEncoder(rw).Encode(items)
+	case r.Method == http.MethodPost:
+		body["id"] = s.nextID
+		s.todos[s.nextID] = body
+		s.nextID++
+		rw.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(rw).Encode(body)
+	case r.Method == http.MethodPut:
+		if _, ok := s.todos[id]; !ok {
+			rw.WriteHeader(http.StatusInternalServerError)
+			fmt.Fprintf(rw, `{"code": 500, "message": "not found: item %d"}`, id)
+			return
+		}
+		body["id"] = id
+		s.todos[id] = body
+		_ = json.NewEncoder(rw).Encode(body)
+	case r.Method == http.MethodDelete:
+		delete(s.todos, id)
+		rw.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func TestWaitForTodo(t *testing.T) {
+	var reads int32
+	c := newTestTodoClient(t, func(w http.ResponseWriter, _ *http.Request) {
+		description := "Go Shopping"
+		if atomic.AddInt32(&reads, 1) < 3 {
+			description = "stale"
+		}
+		w.Header().Set("Content-Type", "application/spkane.todo-list.v1+json")
+		fmt.Fprintf(w, `[{"id": 1, "description": %q, "completed": false}]`, description)
+	})
+
+	match := func(item *models.Item) (bool, error) {
+		return *item.Description == "Go Shopping", nil
+	}
+
+	item, err := waitForTodo(context.Background(), c, 1, 5*time.Second, match)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if *item.Description != "Go Shopping" {
+		t.Errorf("expected the consistent todo, got %q", *item.Description)
+	}
+	if reads != 3 {
+		t.Errorf("expected 3 reads, got %d", reads)
+	}
+
+	atomic.StoreInt32(&reads, 0)
+	item, err = waitForTodo(context.Background(), c, 1, 0, match)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if *item.Description != "stale" {
+		t.Errorf("expected the first read without a timeout, got %q", *item.Description)
+	}
+
+	_, err = waitForTodo(context.Background(), c, 1, 200*time.Millisecond, func(*models.Item) (bool, error) {
+		return false, nil
+	})
+	if err != errTodoNotConsistent {
+		t.Errorf("expected errTodoNotConsistent, got %v", err)
+	}
+}
@@ -2,6 +2,9 @@ package todo
 
 import (
 	"context"
+	"regexp"
+	"strconv"
+	"strings"
 
 	// Todo API Libraries
 	"github.com/spkane/todo-for-terraform/client"
@@ -9,14 +12,29 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ datasource.DataSource              = &todoDataSource{}
-	_ datasource.DataSourceWithConfigure = &todoDataSource{}
+	_ datasource.DataSource                   = &todoDataSource{}
+	_ datasource.DataSourceWithConfigure      = &todoDataSource{}
+	_ datasource.DataSourceWithValidateConfig = &todoDataSource{}
+)
+
+const (
+	// descriptionMatchExact matches the whole description.
+	descriptionMatchExact = "exact"
+	// descriptionMatchPrefix matches the start of the description.
+	descriptionMatchPrefix = "prefix"
+	// descriptionMatchRegex matches the description against a regular expression.
+	descriptionMatchRegex = "regex"
+
+	// maxListedCandidates bounds how many todos a lookup diagnostic lists.
+	maxListedCandidates = 10
 )
 
 // NewTodoDataSource is a helper function to simplify the provider implementation.
@@ -26,14 +44,24 @@ func NewTodoDataSource() datasource.DataSource {
 
 // todoDataSource is the data source implementation.
 type todoDataSource struct {
-	client *client.TodoList
+	client  *client.TodoList
+	keyring *todoKeyring
 }
 
 // todoDataSourceModel maps the data source schema data.
 type todoDataSourceModel struct {
-	ID          types.Int64  `tfsdk:"id"`
-	Description types.String `tfsdk:"description"`
-	Completed   types.Bool   `tfsdk:"completed"`
+	ID               types.Int64            `tfsdk:"id"`
+	DescriptionMatch *descriptionMatchModel `tfsdk:"description_match"`
+	FailIfMissing    types.Bool             `tfsdk:"fail_if_missing"`
+	Exists           types.Bool             `tfsdk:"exists"`
+	Description      types.String           `tfsdk:"description"`
+	Completed        types.Bool             `tfsdk:"completed"`
+}
+
+// descriptionMatchModel maps the description_match lookup key.
+type descriptionMatchModel struct {
+	Value types.String `tfsdk:"value"`
+	Mode  types.String `tfsdk:"mode"`
 }
 
 // Configure adds the provider configured client to the data source.
@@ -42,8 +70,9 @@ func (d *todoDataSource) Configure(_ context.Context, req datasource.ConfigureRe
 		return
 	}
 
-	d.client = req.ProviderData.(*client.TodoList)
-
+	providerData := req.ProviderData.(*todoProviderData)
+	d.client = providerData.client
+	d.keyring = providerData.keyring
 }
 
 // Metadata returns the data source type name.
@@ -54,11 +83,36 @@ func (d *todoDataSource) Metadata(_ context.Context, req datasource.MetadataRequ
 // Schema defines the schema for the data source.
 func (d *todoDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		Description: "Fetch a todo.",
+		Description: "Fetch a todo by its ID or by its description. Exactly one of `id` and `description_match` must be set.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.Int64Attribute{
 				Description: "The unique identifier for the todo.",
-				Required:    true,
+				Optional:    true,
+				Computed:    true,
+			},
+			"description_match": schema.SingleNestedAttribute{
+				Description: "Look the todo up by its description instead of its ID. Exactly one todo must match.",
+				Optional:    true,
+				Attributes: map[string]schema.Attribute{
+					"value": schema.StringAttribute{
+						Description: "The description, description prefix or regular expression to match.",
+						Required:    true,
+					},
+					"mode": schema.StringAttribute{
+						Description: "How value is matched against descriptions: `exact`, `prefix` or `regex` (an RE2 regular expression). Defaults to `exact`.",
+						Optional:    true,
+					},
+				},
+			},
+			"fail_if_missing": schema.BoolAttribute{
+				Description: "Whether to fail when no todo is found (default: true). " +
+					"When false, a missing todo sets exists to false and leaves the other attributes null. " +
+					"Errors reaching the server always fail.",
+				Optional: true,
+			},
+			"exists": schema.BoolAttribute{
+				Description: "Whether the todo was found.",
+				Computed:    true,
 			},
 			"description": schema.StringAttribute{
 				Description: "The description for the todo.",
@@ -72,35 +126,223 @@ func (d *todoDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, r
 	}
 }
 
+// ValidateConfig checks that exactly one lookup key is set.
+func (d *todoDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	var config todoDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	switch {
+	case !config.ID.IsNull() && config.DescriptionMatch != nil:
+		resp.Diagnostics.AddAttributeError(
+			path.Root("description_match"),
+			"Conflicting Todo Lookup Keys",
+			"Only one of id and description_match may be set.",
+		)
+	case config.ID.IsNull() && config.DescriptionMatch == nil:
+		resp.Diagnostics.AddError(
+			"Missing Todo Lookup Key",
+			"One of id or description_match must be set.",
+		)
+	case config.DescriptionMatch != nil:
+		_, diags := newDescriptionMatchFilter(config.DescriptionMatch, path.Root("description_match"))
+		resp.Diagnostics.Append(diags...)
+	}
+}
+
+// newDescriptionMatchFilter builds the todoFilter for a description_match
+// lookup key at the given path. Unknown values are not validated.
+func newDescriptionMatchFilter(model *descriptionMatchModel, attributePath path.Path) (todoFilter, diag.Diagnostics) {
+	var filter todoFilter
+	var diags diag.Diagnostics
+
+	if model.Value.IsUnknown() || model.Mode.IsUnknown() {
+		return filter, diags
+	}
+
+	value := model.Value.ValueString()
+	switch mode := model.Mode.ValueString(); mode {
+	case "", descriptionMatchExact:
+		filter.descriptionEquals = &value
+	case descriptionMatchPrefix:
+		filter.descriptionPrefix = value
+	case descriptionMatchRegex:
+		re, err := regexp.Compile(value)
+		if err != nil {
+			diags.AddAttributeError(
+				attributePath.AtName("value"),
+				"Invalid Description Regex",
+				"The description_match value is not a valid regular expression: "+err.Error(),
+			)
+		}
+		filter.descriptionRegex = re
+	default:
+		diags.AddAttributeError(
+			attributePath.AtName("mode"),
+			"Invalid Description Match Mode",
+			"The description_match mode must be one of "+descriptionMatchExact+", "+descriptionMatchPrefix+" or "+descriptionMatchRegex+", got: "+mode,
+		)
+	}
+	return filter, diags
+}
+
 // Read refreshes the Terraform state with the latest data.
 func (d *todoDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
 	tflog.Debug(ctx, "Preparing to read todo data source")
 	var state todoDataSourceModel
 
 	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	failIfMissing := state.FailIfMissing.IsNull() || state.FailIfMissing.ValueBool()
+
+	var todo *todoItem
+	var diags diag.Diagnostics
+	if state.DescriptionMatch != nil {
+		todo, diags = d.findByDescription(ctx, state.DescriptionMatch, failIfMissing)
+	} else {
+		todo, diags = d.findByID(state.ID.ValueInt64(), failIfMissing)
+	}
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if todo == nil {
+		tflog.Debug(ctx, "Todo not found, returning nulls")
+		state.Exists = types.BoolValue(false)
+		if state.DescriptionMatch != nil {
+			state.ID = types.Int64Null()
+		}
+		state.Description = types.StringNull()
+		state.Completed = types.BoolNull()
+		resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+		return
+	}
+
+	// Map response body to model
+	state.ID = types.Int64Value(todo.ID)
+	state.Exists = types.BoolValue(true)
+	state.Description = types.StringValue(todo.Description)
+	state.Completed = types.BoolValue(todo.Completed)
+
+	// Set state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	tflog.Debug(ctx, "Finished reading todo data source", map[string]any{"success": true})
+}
+
+// findByID reads the todo with the given ID. A missing todo is an error
+// when failIfMissing is set, and nil otherwise.
+func (d *todoDataSource) findByID(id int64, failIfMissing bool) (*todoItem, diag.Diagnostics) {
+	var diags diag.Diagnostics
 
 	params := todos.NewFindTodoParams()
-	params.SetID(state.ID.ValueInt64())
+	params.SetID(id)
 	result, err := d.client.Todos.FindTodo(params)
 
+	if (err == nil && len(result.GetPayload()) == 0) || isTodoNotFound(err) {
+		if failIfMissing {
+			diags.AddAttributeError(
+				path.Root("id"),
+				"Todo Not Found",
+				"No todo has the ID "+strconv.FormatInt(id, 10)+". Set fail_if_missing to false to allow this.",
+			)
+		}
+		return nil, diags
+	}
 	if err != nil {
-		resp.Diagnostics.AddError(
+		diags.AddError(
 			"Unable to Read Todo",
 			err.Error(),
 		)
-		return
+		return nil, diags
 	}
 
-	todo := result.GetPayload()
+	item := result.GetPayload()[0]
 
-	// Map response body to model
-	state = todoDataSourceModel{
-		ID:          types.Int64Value(int64(todo[0].ID)),
-		Description: types.StringValue(*todo[0].Description),
-		Completed:   types.BoolValue(*todo[0].Completed),
+	description, decryptDiags := d.keyring.decryptDescription(item.ID, *item.Description)
+	diags.Append(decryptDiags...)
+	if diags.HasError() {
+		return nil, diags
 	}
 
-	// Set state
-	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
-	tflog.Debug(ctx, "Finished reading todo data source", map[string]any{"success": true})
+	return &todoItem{
+		ID:          item.ID,
+		Description: description,
+		Completed:   *item.Completed,
+	}, diags
+}
+
+// findByDescription pages through the todos on the server and returns the
+// only one matching a description_match lookup key. When none match, the
+// todo is missing: that is an error listing todos containing the value in any
+// letter case as candidates when failIfMissing is set, and nil otherwise.
+func (d *todoDataSource) findByDescription(ctx context.Context, match *descriptionMatchModel, failIfMissing bool) (*todoItem, diag.Diagnostics) {
+	filter, diags := newDescriptionMatchFilter(match, path.Root("description_match"))
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	all, listDiags := listTodos(ctx, d.client, d.keyring, todoFilter{}, 0)
+	diags.Append(listDiags...)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	value := match.Value.ValueString()
+	var matched, similar []todoItem
+	for _, todo := range all {
+		switch {
+		case filter.matches(todo):
+			matched = append(matched, todo)
+		case filter.descriptionRegex == nil && strings.Contains(strings.ToLower(todo.Description), strings.ToLower(value)):
+			similar = append(similar, todo)
+		}
+	}
+
+	switch {
+	case len(matched) == 1:
+		return &matched[0], diags
+	case len(matched) > 1:
+		diags.AddAttributeError(
+			path.Root("description_match"),
+			"Multiple Todos Found",
+			strconv.Itoa(len(matched))+" todos have a description matching "+strconv.Quote(value)+
+				", but exactly one must match. Use a more specific description_match or look the todo up by id.\n\n"+
+				"Candidates:\n"+describeCandidates(matched),
+		)
+	case !failIfMissing:
+	case len(similar) > 0:
+		diags.AddAttributeError(
+			path.Root("description_match"),
+			"Todo Not Found",
+			"No todo has a description matching "+strconv.Quote(value)+".\n\n"+
+				"Similar todos:\n"+describeCandidates(similar),
+		)
+	default:
+		diags.AddAttributeError(
+			path.Root("description_match"),
+			"Todo Not Found",
+			"No todo has a description matching "+strconv.Quote(value)+".",
+		)
+	}
+	return nil, diags
+}
+
+// describeCandidates lists todos for a lookup diagnostic, one per line.
+func describeCandidates(candidates []todoItem) string {
+	var lines []string
+	for i, todo := range candidates {
+		if i == maxListedCandidates {
+			lines = append(lines, "  ... and "+strconv.Itoa(len(candidates)-i)+" more")
+			break
+		}
+		lines = append(lines, "  - ID "+strconv.FormatInt(todo.ID, 10)+": "+strconv.Quote(todo.Description))
+	}
+	return strings.Join(lines, "\n")
 }
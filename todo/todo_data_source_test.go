@@ -1,6 +1,7 @@
 package todo
 
 import (
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
@@ -26,6 +27,7 @@ data "todo_todo" "test" {
 					// Verify the todo to ensure all attributes are set
 					resource.TestCheckResourceAttr("data.todo_todo.test", "description", "Go Shopping"),
 					resource.TestCheckResourceAttr("data.todo_todo.test", "completed", "false"),
+					resource.TestCheckResourceAttr("data.todo_todo.test", "exists", "true"),
 					// Verify placeholder id attribute
 					resource.TestCheckResourceAttrSet("data.todo_todo.test", "id"),
 				),
@@ -33,3 +35,128 @@ data "todo_todo" "test" {
 		},
 	})
 }
+
+func TestAccTodoDataSourceDescriptionMatch(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: providerConfig + `
+resource "todo_todo" "test" {
+	description = "[acc-match] Go Shopping"
+	completed = false
+}
+
+resource "todo_todo" "other" {
+	description = "[acc-match] Walk the dog"
+	completed = true
+}
+
+data "todo_todo" "exact" {
+	description_match = {
+		value = todo_todo.test.description
+	}
+}
+
+data "todo_todo" "regex" {
+	description_match = {
+		value = "^\\[acc-match\\] Walk"
+		mode  = "regex"
+	}
+
+	depends_on = [todo_todo.other]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.todo_todo.exact", "id", "todo_todo.test", "id"),
+					resource.TestCheckResourceAttr("data.todo_todo.exact", "completed", "false"),
+					resource.TestCheckResourceAttrPair("data.todo_todo.regex", "id", "todo_todo.other", "id"),
+					resource.TestCheckResourceAttr("data.todo_todo.regex", "description", "[acc-match] Walk the dog"),
+				),
+			},
+			// Ambiguous lookups fail
+			{
+				Config: providerConfig + `
+resource "todo_todo" "test" {
+	description = "[acc-match] Go Shopping"
+	completed = false
+}
+
+resource "todo_todo" "other" {
+	description = "[acc-match] Walk the dog"
+	completed = true
+}
+
+data "todo_todo" "prefix" {
+	description_match = {
+		value = "[acc-match]"
+		mode  = "prefix"
+	}
+
+	depends_on = [todo_todo.test, todo_todo.other]
+}
+`,
+				ExpectError: regexp.MustCompile("Multiple Todos Found"),
+			},
+		},
+	})
+}
+
+func TestAccTodoDataSourceLookupKeys(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      providerConfig + `data "todo_todo" "test" {}`,
+				ExpectError: regexp.MustCompile("Missing Todo Lookup Key"),
+			},
+			{
+				Config: providerConfig + `
+data "todo_todo" "test" {
+	id = 1
+	description_match = {
+		value = "Go Shopping"
+	}
+}
+`,
+				ExpectError: regexp.MustCompile("Conflicting Todo Lookup Keys"),
+			},
+		},
+	})
+}
+
+func TestAccTodoDataSourceMissing(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Missing todos fail by default
+			{
+				Config: providerConfig + `
+data "todo_todo" "test" {
+	description_match = {
+		value = "[acc-missing] Never created"
+	}
+}
+`,
+				ExpectError: regexp.MustCompile("Todo Not Found"),
+			},
+			// Missing todos are reported when allowed
+			{
+				Config: providerConfig + `
+data "todo_todo" "test" {
+	description_match = {
+		value = "[acc-missing] Never created"
+	}
+	fail_if_missing = false
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.todo_todo.test", "exists", "false"),
+					resource.TestCheckNoResourceAttr("data.todo_todo.test", "id"),
+					resource.TestCheckNoResourceAttr("data.todo_todo.test", "description"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,185 @@
+package todo
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+const (
+	// descriptionNormalizationExact compares descriptions byte for byte.
+	descriptionNormalizationExact = "exact"
+	// descriptionNormalizationUnicode ignores trailing whitespace and
+	// differences between Unicode normalization forms (e.g. NFC and NFD).
+	descriptionNormalizationUnicode = "unicode"
+	// descriptionNormalizationCaseInsensitive additionally ignores case.
+	descriptionNormalizationCaseInsensitive = "case_insensitive"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ basetypes.StringTypable                    = descriptionType{}
+	_ basetypes.StringValuableWithSemanticEquals = descriptionValue{}
+)
+
+// descriptionNormalizer compares descriptions under the provider's
+// description_normalization setting. Configure builds a new one every time
+// it runs.
+type descriptionNormalizer struct {
+	mode string
+}
+
+// descriptionNormalizers hands the normalizer of the latest provider
+// configuration to description values. Schemas are built before the
+// provider is configured, so descriptionType refers to it instead of to a
+// normalizer, and each value keeps the normalizer that was current when it
+// was built.
+type descriptionNormalizers struct {
+	current atomic.Pointer[descriptionNormalizer]
+}
+
+// get returns the current normalizer, or nil if the provider has not been
+// configured.
+func (n *descriptionNormalizers) get() *descriptionNormalizer {
+	if n == nil {
+		return nil
+	}
+	return n.current.Load()
+}
+
+// set makes normalizer the current normalizer.
+func (n *descriptionNormalizers) set(normalizer *descriptionNormalizer) {
+	n.current.Store(normalizer)
+}
+
+// equal reports whether two descriptions are the same under the configured
+// normalization mode. A nil normalizer compares exactly.
+func (n *descriptionNormalizer) equal(a, b string) bool {
+	if n == nil || n.mode == "" || n.mode == descriptionNormalizationExact {
+		return a == b
+	}
+	return normalizeDescription(a, n.mode) == normalizeDescription(b, n.mode)
+}
+
+// normalizeDescription returns the canonical form of a description for the
+// given normalization mode.
+func normalizeDescription(description, mode string) string {
+	if mode == "" || mode == descriptionNormalizationExact {
+		return description
+	}
+
+	description = norm.NFC.String(strings.TrimRightFunc(description, unicode.IsSpace))
+	if mode == descriptionNormalizationCaseInsensitive {
+		description = strings.ToLower(description)
+	}
+	return description
+}
+
+// descriptionType is the custom string type for todo descriptions.
+type descriptionType struct {
+	basetypes.StringType
+
+	normalizers *descriptionNormalizers
+}
+
+// Equal returns true if the given type is a descriptionType.
+func (t descriptionType) Equal(o attr.Type) bool {
+	_, ok := o.(descriptionType)
+	return ok
+}
+
+// String returns a human readable string of the type name.
+func (t descriptionType) String() string {
+	return "todo.descriptionType"
+}
+
+// ValueFromString returns a descriptionValue given a StringValue.
+func (t descriptionType) ValueFromString(_ context.Context, in basetypes.StringValue) (basetypes.StringValuable, diag.Diagnostics) {
+	return descriptionValue{
+		StringValue: in,
+		normalizers: t.normalizers,
+		normalizer:  t.normalizers.get(),
+	}, nil
+}
+
+// ValueFromTerraform returns a descriptionValue given a tftypes.Value.
+func (t descriptionType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	attrValue, err := t.StringType.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	stringValue, ok := attrValue.(basetypes.StringValue)
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type of %T", attrValue)
+	}
+
+	stringValuable, diags := t.ValueFromString(ctx, stringValue)
+	if diags.HasError() {
+		return nil, fmt.Errorf("unexpected error converting StringValue to StringValuable: %v", diags)
+	}
+	return stringValuable, nil
+}
+
+// ValueType returns the value type of this type.
+func (t descriptionType) ValueType(_ context.Context) attr.Value {
+	return descriptionValue{normalizers: t.normalizers, normalizer: t.normalizers.get()}
+}
+
+// descriptionValue is the value of a descriptionType.
+type descriptionValue struct {
+	basetypes.StringValue
+
+	normalizers *descriptionNormalizers
+	normalizer  *descriptionNormalizer
+}
+
+// newDescriptionValue returns a known descriptionValue.
+func newDescriptionValue(value string) descriptionValue {
+	return descriptionValue{StringValue: basetypes.NewStringValue(value)}
+}
+
+// Equal returns true if the given value is a descriptionValue with the
+// same underlying string.
+func (v descriptionValue) Equal(o attr.Value) bool {
+	other, ok := o.(descriptionValue)
+	if !ok {
+		return false
+	}
+	return v.StringValue.Equal(other.StringValue)
+}
+
+// Type returns the type of this value.
+func (v descriptionValue) Type(_ context.Context) attr.Type {
+	return descriptionType{normalizers: v.normalizers}
+}
+
+// StringSemanticEquals returns true if the descriptions only differ in ways
+// the provider's description_normalization setting ignores, in which case
+// the prior value is kept and no drift is reported.
+func (v descriptionValue) StringSemanticEquals(_ context.Context, newValuable basetypes.StringValuable) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	newValue, ok := newValuable.(descriptionValue)
+	if !ok {
+		diags.AddError(
+			"Semantic Equality Check Error",
+			"An unexpected value type was received while performing semantic equality checks. "+
+				"Please report this to the provider developers.\n\n"+
+				"Expected Value Type: "+fmt.Sprintf("%T", v)+"\n"+
+				"Got Value Type: "+fmt.Sprintf("%T", newValuable),
+		)
+		return false, diags
+	}
+
+	return v.normalizer.equal(v.ValueString(), newValue.ValueString()), diags
+}
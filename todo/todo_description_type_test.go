@@ -0,0 +1,100 @@
+package todo
+
+import (
+	"context"
+	"testing"
+)
+
+func TestDescriptionValueSemanticEquals(t *testing.T) {
+	testCases := map[string]struct {
+		mode     string
+		prior    string
+		new      string
+		expected bool
+	}{
+		"exact-equal": {
+			mode:     descriptionNormalizationExact,
+			prior:    "Go Shopping",
+			new:      "Go Shopping",
+			expected: true,
+		},
+		"exact-trailing-whitespace": {
+			mode:     descriptionNormalizationExact,
+			prior:    "Go Shopping ",
+			new:      "Go Shopping",
+			expected: false,
+		},
+		"unicode-trailing-whitespace": {
+			mode:     descriptionNormalizationUnicode,
+			prior:    "Go Shopping \n",
+			new:      "Go Shopping",
+			expected: true,
+		},
+		"unicode-leading-whitespace": {
+			mode:     descriptionNormalizationUnicode,
+			prior:    " Go Shopping",
+			new:      "Go Shopping",
+			expected: false,
+		},
+		"unicode-nfd": {
+			mode:     descriptionNormalizationUnicode,
+			prior:    "Caf\u00e9",
+			new:      "Cafe\u0301",
+			expected: true,
+		},
+		"unicode-case": {
+			mode:     descriptionNormalizationUnicode,
+			prior:    "Go Shopping",
+			new:      "go shopping",
+			expected: false,
+		},
+		"case-insensitive-case": {
+			mode:     descriptionNormalizationCaseInsensitive,
+			prior:    "Go Shopping",
+			new:      "go shopping ",
+			expected: true,
+		},
+		"case-insensitive-different": {
+			mode:     descriptionNormalizationCaseInsensitive,
+			prior:    "Go Shopping",
+			new:      "Walk the dog",
+			expected: false,
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+		t.Run(name, func(t *testing.T) {
+			normalizer := &descriptionNormalizer{mode: testCase.mode}
+			prior := descriptionValue{StringValue: newDescriptionValue(testCase.prior).StringValue, normalizer: normalizer}
+			proposed := descriptionValue{StringValue: newDescriptionValue(testCase.new).StringValue, normalizer: normalizer}
+
+			got, diags := proposed.StringSemanticEquals(context.Background(), prior)
+			if diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+			if got != testCase.expected {
+				t.Errorf("expected %t, got %t", testCase.expected, got)
+			}
+		})
+	}
+}
+
+func TestDescriptionTypeKeepsNormalizerOfValue(t *testing.T) {
+	normalizers := &descriptionNormalizers{}
+	normalizers.set(&descriptionNormalizer{mode: descriptionNormalizationCaseInsensitive})
+	valueType := descriptionType{normalizers: normalizers}
+
+	prior, _ := valueType.ValueFromString(context.Background(), newDescriptionValue("Go Shopping").StringValue)
+
+	// Configuring the provider again does not change values already built
+	normalizers.set(&descriptionNormalizer{mode: descriptionNormalizationExact})
+	proposed, _ := valueType.ValueFromString(context.Background(), newDescriptionValue("go shopping").StringValue)
+
+	if got, _ := prior.(descriptionValue).StringSemanticEquals(context.Background(), proposed); !got {
+		t.Error("expected the value built before to keep comparing case insensitively")
+	}
+	if got, _ := proposed.(descriptionValue).StringSemanticEquals(context.Background(), prior); got {
+		t.Error("expected the value built after to compare exactly")
+	}
+}
@@ -0,0 +1,198 @@
+package todo
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// encryptedDescriptionPrefix marks a description encrypted by the provider.
+//
+// Encrypted descriptions have the form
+//
+//	todoenc:v1:<key id>:<wrapped data key>:<sealed description>
+//
+// Each description is sealed with a random AES-256-GCM data key, which is in
+// turn sealed with the keyring key named by the key ID. Both sealed values
+// are raw URL base64 encoded and carry their GCM nonce as a prefix.
+const encryptedDescriptionPrefix = "todoenc:v1:"
+
+// todoKeyring holds the keys used to encrypt and decrypt todo descriptions.
+// A nil keyring leaves descriptions untouched.
+type todoKeyring struct {
+	currentID string
+	keys      map[string]cipher.AEAD
+}
+
+// newTodoKeyring builds a keyring from base64 encoded AES keys. The current
+// key encrypts new descriptions, while previous keys are only used to
+// decrypt descriptions written before a key rotation. A nil keyring is
+// returned when no current key is given.
+func newTodoKeyring(current string, previous []string) (*todoKeyring, error) {
+	if current == "" {
+		if len(previous) > 0 {
+			return nil, errors.New("previous encryption keys require a current encryption key")
+		}
+		return nil, nil
+	}
+
+	keyring := &todoKeyring{
+		keys: make(map[string]cipher.AEAD),
+	}
+
+	for i, encoded := range append([]string{current}, previous...) {
+		id, aead, err := parseEncryptionKey(encoded)
+		if err != nil {
+			if i == 0 {
+				return nil, fmt.Errorf("invalid current encryption key: %w", err)
+			}
+			return nil, fmt.Errorf("invalid previous encryption key %d: %w", i, err)
+		}
+		if i == 0 {
+			keyring.currentID = id
+		}
+		keyring.keys[id] = aead
+	}
+	return keyring, nil
+}
+
+// parseEncryptionKey decodes a base64 encoded AES key and derives its key ID.
+func parseEncryptionKey(encoded string) (string, cipher.AEAD, error) {
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+	if err != nil {
+		return "", nil, fmt.Errorf("key is not valid base64: %w", err)
+	}
+
+	aead, err := newGCM(key)
+	if err != nil {
+		return "", nil, err
+	}
+
+	sum := sha256.Sum256(key)
+	return hex.EncodeToString(sum[:4]), aead, nil
+}
+
+// newGCM returns an AES-GCM AEAD for a 16, 24 or 32 byte key.
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// encrypt seals a plaintext description with the current key.
+func (k *todoKeyring) encrypt(description string) (string, error) {
+	if k == nil {
+		return description, nil
+	}
+
+	dataKey := make([]byte, 32)
+	if _, err := rand.Read(dataKey); err != nil {
+		return "", err
+	}
+	dataAEAD, err := newGCM(dataKey)
+	if err != nil {
+		return "", err
+	}
+
+	header := encryptedDescriptionPrefix + k.currentID
+	wrappedKey, err := sealEncoded(k.keys[k.currentID], dataKey, header)
+	if err != nil {
+		return "", err
+	}
+	sealed, err := sealEncoded(dataAEAD, []byte(description), header)
+	if err != nil {
+		return "", err
+	}
+
+	return header + ":" + wrappedKey + ":" + sealed, nil
+}
+
+// decrypt opens an encrypted description. Descriptions without the
+// encrypted prefix are plaintext and returned as-is.
+func (k *todoKeyring) decrypt(description string) (string, error) {
+	if !strings.HasPrefix(description, encryptedDescriptionPrefix) {
+		return description, nil
+	}
+
+	parts := strings.Split(strings.TrimPrefix(description, encryptedDescriptionPrefix), ":")
+	if len(parts) != 3 {
+		return "", errors.New("malformed encrypted description")
+	}
+	id, wrappedKey, sealed := parts[0], parts[1], parts[2]
+
+	if k == nil {
+		return "", fmt.Errorf("description is encrypted with key ID %s but no encryption_key is configured", id)
+	}
+	keyAEAD, ok := k.keys[id]
+	if !ok {
+		return "", fmt.Errorf("description is encrypted with key ID %s, which is not in the keyring", id)
+	}
+
+	header := encryptedDescriptionPrefix + id
+	dataKey, err := openEncoded(keyAEAD, wrappedKey, header)
+	if err != nil {
+		return "", fmt.Errorf("could not unwrap data key with key ID %s: %w", id, err)
+	}
+	dataAEAD, err := newGCM(dataKey)
+	if err != nil {
+		return "", err
+	}
+	plaintext, err := openEncoded(dataAEAD, sealed, header)
+	if err != nil {
+		return "", fmt.Errorf("could not decrypt description with key ID %s: %w", id, err)
+	}
+	return string(plaintext), nil
+}
+
+// decryptDescription decrypts a description read from the Todo server,
+// returning a diagnostic that names the todo if it cannot be decrypted.
+func (k *todoKeyring) decryptDescription(id int64, description string) (string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	plaintext, err := k.decrypt(description)
+	if err != nil {
+		diags.AddError(
+			"Unable to Decrypt Todo Description",
+			"The description of todo ID "+strconv.FormatInt(id, 10)+" could not be decrypted. "+
+				"Ensure the key it was encrypted with is set as the provider encryption_key or listed in previous_encryption_keys.\n\n"+
+				"Decryption Error: "+err.Error(),
+		)
+		return "", diags
+	}
+	return plaintext, diags
+}
+
+// sealEncoded encrypts plaintext with a random nonce and returns the nonce and
+// ciphertext as raw URL base64.
+func sealEncoded(aead cipher.AEAD, plaintext []byte, additionalData string) (string, error) {
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	sealed := aead.Seal(nonce, nonce, plaintext, []byte(additionalData))
+	return base64.RawURLEncoding.EncodeToString(sealed), nil
+}
+
+// openEncoded reverses sealEncoded.
+func openEncoded(aead cipher.AEAD, encoded string, additionalData string) ([]byte, error) {
+	sealed, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, err
+	}
+	if len(sealed) < aead.NonceSize() {
+		return nil, errors.New("ciphertext too short")
+	}
+	nonce, ciphertext := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+	return aead.Open(nil, nonce, ciphertext, []byte(additionalData))
+}
@@ -0,0 +1,103 @@
+package todo
+
+import (
+	"strings"
+	"testing"
+)
+
+const (
+	testEncryptionKey         = "MDEyMzQ1Njc4OWFiY2RlZjAxMjM0NTY3ODlhYmNkZWY="
+	testPreviousEncryptionKey = "ZmVkY2JhOTg3NjU0MzIxMGZlZGNiYTk4NzY1NDMyMTA="
+)
+
+func TestTodoKeyring(t *testing.T) {
+	keyring, err := newTodoKeyring(testEncryptionKey, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	encrypted, err := keyring.encrypt("Rotate the incident credentials")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !strings.HasPrefix(encrypted, encryptedDescriptionPrefix+keyring.currentID+":") {
+		t.Errorf("expected key ID %s to be embedded in %q", keyring.currentID, encrypted)
+	}
+	if strings.Contains(encrypted, "incident") {
+		t.Errorf("expected description to be encrypted, got %q", encrypted)
+	}
+
+	decrypted, err := keyring.decrypt(encrypted)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if decrypted != "Rotate the incident credentials" {
+		t.Errorf("expected round trip, got %q", decrypted)
+	}
+
+	plaintext, err := keyring.decrypt("Go Shopping")
+	if err != nil || plaintext != "Go Shopping" {
+		t.Errorf("expected plaintext to pass through, got %q, %v", plaintext, err)
+	}
+
+	flipped := byte('A')
+	if encrypted[len(encrypted)-5] == flipped {
+		flipped = 'B'
+	}
+	tampered := encrypted[:len(encrypted)-5] + string(flipped) + encrypted[len(encrypted)-4:]
+	if _, err := keyring.decrypt(tampered); err == nil {
+		t.Errorf("expected tampered description to fail to decrypt")
+	}
+
+	var disabled *todoKeyring
+	if _, err := disabled.decrypt(encrypted); err == nil {
+		t.Errorf("expected encrypted description to fail without a keyring")
+	}
+}
+
+func TestTodoKeyringRotation(t *testing.T) {
+	previous, err := newTodoKeyring(testPreviousEncryptionKey, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	encrypted, err := previous.encrypt("Go Shopping")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	current, err := newTodoKeyring(testEncryptionKey, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if _, err := current.decrypt(encrypted); err == nil {
+		t.Errorf("expected description to fail to decrypt without the previous key")
+	}
+
+	rotated, err := newTodoKeyring(testEncryptionKey, []string{testPreviousEncryptionKey})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	decrypted, err := rotated.decrypt(encrypted)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if decrypted != "Go Shopping" {
+		t.Errorf("expected round trip, got %q", decrypted)
+	}
+}
+
+func TestNewTodoKeyringInvalid(t *testing.T) {
+	if _, err := newTodoKeyring("not base64!", nil); err == nil {
+		t.Errorf("expected an error for a key that is not base64")
+	}
+	if _, err := newTodoKeyring("c2hvcnQ=", nil); err == nil {
+		t.Errorf("expected an error for a key with an invalid length")
+	}
+	if _, err := newTodoKeyring("", []string{testPreviousEncryptionKey}); err == nil {
+		t.Errorf("expected an error for previous keys without a current key")
+	}
+	keyring, err := newTodoKeyring("", nil)
+	if err != nil || keyring != nil {
+		t.Errorf("expected no keyring without keys, got %v, %v", keyring, err)
+	}
+}
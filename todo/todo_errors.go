@@ -0,0 +1,35 @@
+package todo
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	// Todo API Libraries
+	"github.com/spkane/todo-for-terraform/models"
+)
+
+// todoAPIError is satisfied by the default error responses of the Todo API
+// client, such as todos.FindTodoDefault and todos.DestroyOneDefault.
+type todoAPIError interface {
+	error
+	Code() int
+	GetPayload() *models.Error
+}
+
+// isTodoNotFound reports whether a Todo API error means the todo does not
+// exist. The Todo server answers with a 500 whose message starts with "not
+// found" rather than a 404, so both are accepted. Any other error, such as
+// a connection failure, is not a not-found.
+func isTodoNotFound(err error) bool {
+	var apiErr todoAPIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	if apiErr.Code() == http.StatusNotFound {
+		return true
+	}
+
+	payload := apiErr.GetPayload()
+	return payload != nil && payload.Message != nil && strings.HasPrefix(*payload.Message, "not found")
+}
@@ -0,0 +1,69 @@
+package todo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	// Todo API Libraries
+	"github.com/spkane/todo-for-terraform/client/todos"
+)
+
+func TestIsTodoNotFound(t *testing.T) {
+	testCases := map[string]struct {
+		status   int
+		body     string
+		expected bool
+	}{
+		"todo-server": {
+			status:   http.StatusInternalServerError,
+			body:     `{"code": 500, "message": "not found: item 7"}`,
+			expected: true,
+		},
+		"status": {
+			status:   http.StatusNotFound,
+			body:     `{"code": 404, "message": "no such todo"}`,
+			expected: true,
+		},
+		"server-error": {
+			status:   http.StatusInternalServerError,
+			body:     `{"code": 500, "message": "database is locked"}`,
+			expected: false,
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+		t.Run(name, func(t *testing.T) {
+			c := newTestTodoClient(t, func(w http.ResponseWriter, _ *http.Request) {
+				w.Header().Set("Content-Type", "application/spkane.todo-list.v1+json")
+				w.WriteHeader(testCase.status)
+				fmt.Fprint(w, testCase.body)
+			})
+
+			params := todos.NewFindTodoParamsWithContext(context.Background())
+			params.SetID(7)
+			_, err := c.Todos.FindTodo(params)
+			if got := isTodoNotFound(err); got != testCase.expected {
+				t.Errorf("expected %t for %v", testCase.expected, err)
+			}
+		})
+	}
+
+	c := newTestTodoClient(t, func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/spkane.todo-list.v1+json")
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprint(w, `{"code": 500, "message": "not found: item 7"}`)
+	})
+	params := todos.NewDestroyOneParamsWithContext(context.Background())
+	params.SetID(7)
+	if _, err := c.Todos.DestroyOne(params); !isTodoNotFound(err) {
+		t.Errorf("expected a not-found for DestroyOne, got %v", err)
+	}
+
+	if isTodoNotFound(errors.New("connection refused")) {
+		t.Errorf("expected a connection error not to be a not-found")
+	}
+}
@@ -0,0 +1,476 @@
+package todo
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// defaultMaxDeletions is how many foreign todos todo_exclusive deletes in a
+// single apply by default.
+const defaultMaxDeletions = 10
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource               = &todoExclusiveResource{}
+	_ resource.ResourceWithConfigure  = &todoExclusiveResource{}
+	_ resource.ResourceWithModifyPlan = &todoExclusiveResource{}
+)
+
+// NewTodoExclusiveResource is a helper function to simplify the provider implementation.
+func NewTodoExclusiveResource() resource.Resource {
+	return &todoExclusiveResource{}
+}
+
+// todoExclusiveResource is the resource implementation.
+type todoExclusiveResource struct {
+	writer *todoWriter
+}
+
+// todoExclusiveResourceModel maps the resource schema data.
+type todoExclusiveResourceModel struct {
+	ID                types.String `tfsdk:"id"`
+	DescriptionPrefix types.String `tfsdk:"description_prefix"`
+	AllowedIDs        types.Set    `tfsdk:"allowed_ids"`
+	DryRun            types.Bool   `tfsdk:"dry_run"`
+	MaxDeletions      types.Int64  `tfsdk:"max_deletions"`
+	ForeignIDs        types.List   `tfsdk:"foreign_ids"`
+	DeletedIDs        types.List   `tfsdk:"deleted_ids"`
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *todoExclusiveResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	r.writer = newTodoWriter(req.ProviderData.(*todoProviderData))
+}
+
+// Metadata returns the resource type name.
+func (r *todoExclusiveResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_exclusive"
+}
+
+// Schema defines the schema for the resource.
+func (r *todoExclusiveResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Make this configuration authoritative for the todos on the server, or for those whose description " +
+			"starts with a prefix, by deleting every todo in scope that is not allowed. The plan lists the foreign todos " +
+			"that will be deleted. Destroying the resource deletes nothing.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "A unique identifier for the exclusive scope.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"description_prefix": schema.StringAttribute{
+				Description: "Only todos whose description starts with this text are in scope. Without it, every todo on the server is. " +
+					"It must be known when planning unless dry_run is set.",
+				Optional: true,
+			},
+			"allowed_ids": schema.SetAttribute{
+				Description: "The unique identifiers of the todos to keep, typically the IDs of the todos this configuration manages. " +
+					"They must be known when planning unless dry_run is set, so no todo is deleted that the plan did not list.",
+				Required:    true,
+				ElementType: types.Int64Type,
+			},
+			"dry_run": schema.BoolAttribute{
+				Description: "Only report foreign todos in foreign_ids instead of deleting them (default: false).",
+				Optional:    true,
+			},
+			"max_deletions": schema.Int64Attribute{
+				Description: "Fail instead of deleting more than this many todos in a single apply (default: 10).",
+				Optional:    true,
+			},
+			"foreign_ids": schema.ListAttribute{
+				Description: "The unique identifiers of the foreign todos in scope that are not deleted, because of dry_run " +
+					"or because deleting them failed, in ascending order.",
+				Computed:    true,
+				ElementType: types.Int64Type,
+			},
+			"deleted_ids": schema.ListAttribute{
+				Description: "The unique identifiers of the foreign todos deleted by the latest apply that found any, in ascending order.",
+				Computed:    true,
+				ElementType: types.Int64Type,
+			},
+		},
+	}
+}
+
+// ModifyPlan lists the foreign todos in scope with FindTodos, planning them
+// to be deleted unless dry_run is set.
+func (r *todoExclusiveResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to plan when the resource is being destroyed
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan todoExclusiveResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	maxDeletions, diags := parseMaxDeletions(plan.MaxDeletions)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Deleting todos that no plan listed is refused, so the scope, allowed
+	// IDs and dry_run have to be known unless nothing is deleted
+	allowed, known, diags := exclusiveAllowedIDs(ctx, plan.AllowedIDs)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if (plan.DescriptionPrefix.IsUnknown() || !known || plan.DryRun.IsUnknown()) && !plan.DryRun.ValueBool() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("allowed_ids"),
+			"Foreign Todos Not Known",
+			"The description_prefix, allowed_ids or dry_run are not known until apply, so the plan cannot list the foreign todos "+
+				"that would be deleted. Apply the resources they depend on first, for example with -target, or set dry_run to true.",
+		)
+		return
+	}
+
+	// The foreign todos are not known until the allowed IDs are
+	if plan.DescriptionPrefix.IsUnknown() || !known || r.writer == nil {
+		plan.ForeignIDs = types.ListUnknown(types.Int64Type)
+		plan.DeletedIDs = types.ListUnknown(types.Int64Type)
+		resp.Diagnostics.Append(resp.Plan.Set(ctx, plan)...)
+		return
+	}
+
+	foreign, diags := r.findForeign(ctx, plan.DescriptionPrefix, allowed)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleted := types.ListValueMust(types.Int64Type, nil)
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("deleted_ids"), &deleted)...)
+	}
+
+	switch {
+	case plan.DryRun.ValueBool() || len(foreign) == 0:
+		plan.ForeignIDs, diags = types.ListValueFrom(ctx, types.Int64Type, foreign)
+		resp.Diagnostics.Append(diags...)
+		plan.DeletedIDs = deleted
+	case int64(len(foreign)) > maxDeletions:
+		resp.Diagnostics.AddAttributeError(
+			path.Root("max_deletions"),
+			"Too Many Foreign Todos",
+			strconv.Itoa(len(foreign))+" foreign todos would be deleted, more than max_deletions ("+strconv.FormatInt(maxDeletions, 10)+
+				") allows. Check the scope and allowed_ids, or raise max_deletions. Foreign todos: "+joinIDs(foreign),
+		)
+		return
+	default:
+		plan.ForeignIDs = types.ListValueMust(types.Int64Type, nil)
+		plan.DeletedIDs, diags = types.ListValueFrom(ctx, types.Int64Type, foreign)
+		resp.Diagnostics.Append(diags...)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, plan)...)
+}
+
+// Create deletes the foreign todos in scope.
+func (r *todoExclusiveResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	tflog.Debug(ctx, "Preparing to create todo exclusive resource")
+	// Retrieve values from plan
+	var plan todoExclusiveResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id, err := uuid.GenerateUUID()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Creating Exclusive Scope",
+			"Could not generate an ID, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	plan.ID = types.StringValue(id)
+
+	resp.Diagnostics.Append(r.enforce(ctx, &plan, types.ListNull(types.Int64Type))...)
+	if plan.ForeignIDs.IsUnknown() || plan.DeletedIDs.IsUnknown() {
+		return
+	}
+
+	// Save the deletions that were made even if others failed
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+	tflog.Debug(ctx, "Created todo exclusive resource", map[string]any{"success": !resp.Diagnostics.HasError()})
+}
+
+// Read refreshes the foreign todos in scope, so new ones are planned to be
+// deleted.
+func (r *todoExclusiveResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	tflog.Debug(ctx, "Preparing to read todo exclusive resource")
+	// Get current state
+	var state todoExclusiveResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	allowed, _, diags := exclusiveAllowedIDs(ctx, state.AllowedIDs)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	foreign, diags := r.findForeign(ctx, state.DescriptionPrefix, allowed)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	state.ForeignIDs, diags = types.ListValueFrom(ctx, types.Int64Type, foreign)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	tflog.Debug(ctx, "Finished reading todo exclusive resource", map[string]any{"foreign": len(foreign)})
+}
+
+// Update deletes the foreign todos in scope.
+func (r *todoExclusiveResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	tflog.Debug(ctx, "Preparing to update todo exclusive resource")
+	// Retrieve values from plan
+	var plan todoExclusiveResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var state todoExclusiveResourceModel
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.enforce(ctx, &plan, state.DeletedIDs)...)
+	if plan.ForeignIDs.IsUnknown() || plan.DeletedIDs.IsUnknown() {
+		// Nothing was deleted, so keep the prior state
+		resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+		return
+	}
+
+	// Save the deletions that were made even if others failed
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+	tflog.Debug(ctx, "Updated todo exclusive resource", map[string]any{"success": !resp.Diagnostics.HasError()})
+}
+
+// Delete removes the exclusive scope from state without touching the server.
+func (r *todoExclusiveResource) Delete(ctx context.Context, _ resource.DeleteRequest, _ *resource.DeleteResponse) {
+	tflog.Debug(ctx, "Deleted todo exclusive resource", map[string]any{"success": true})
+}
+
+// enforce deletes the foreign todos in scope for plan, given the deleted_ids
+// of the prior state, and sets foreign_ids and deleted_ids.
+//
+// Todos are only deleted if the plan listed them in deleted_ids and they are
+// still foreign. If the plan could not list them, the foreign todos are only
+// listed in foreign_ids, so that nothing is deleted that no plan showed, and
+// the next plan deletes them. A
+// deleted_ids carried over unchanged from the prior state lists todos
+// deleted by an earlier apply, so nothing is deleted.
+func (r *todoExclusiveResource) enforce(ctx context.Context, plan *todoExclusiveResourceModel, priorDeleted types.List) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	maxDeletions, maxDiags := parseMaxDeletions(plan.MaxDeletions)
+	diags.Append(maxDiags...)
+	allowed, _, allowedDiags := exclusiveAllowedIDs(ctx, plan.AllowedIDs)
+	diags.Append(allowedDiags...)
+	if diags.HasError() {
+		return diags
+	}
+
+	foreign, findDiags := r.findForeign(ctx, plan.DescriptionPrefix, allowed)
+	diags.Append(findDiags...)
+	if diags.HasError() {
+		return diags
+	}
+
+	var planned []int64
+	switch {
+	case plan.DryRun.ValueBool():
+	case plan.DeletedIDs.IsUnknown():
+		if len(foreign) > 0 {
+			diags.AddWarning(
+				"Foreign Todos Not Deleted",
+				"The foreign todos were not known when planning, so they were not deleted. "+
+					"Apply again to review and delete them. Foreign todos: "+joinIDs(foreign),
+			)
+		}
+	case !plan.DeletedIDs.Equal(priorDeleted):
+		diags.Append(plan.DeletedIDs.ElementsAs(ctx, &planned, false)...)
+	}
+	if diags.HasError() {
+		return diags
+	}
+
+	isForeign := make(map[int64]bool, len(foreign))
+	for _, id := range foreign {
+		isForeign[id] = true
+	}
+	var doomed []int64
+	for _, id := range planned {
+		if isForeign[id] {
+			doomed = append(doomed, id)
+		}
+	}
+	if int64(len(doomed)) > maxDeletions {
+		diags.AddAttributeError(
+			path.Root("max_deletions"),
+			"Too Many Foreign Todos",
+			strconv.Itoa(len(doomed))+" foreign todos would be deleted, more than max_deletions ("+strconv.FormatInt(maxDeletions, 10)+
+				") allows. Check the scope and allowed_ids, or raise max_deletions. Foreign todos: "+joinIDs(doomed),
+		)
+		return diags
+	}
+
+	// Delete the foreign todos, recording the ones that could not be deleted
+	deleted := map[int64]bool{}
+	failed := []int64{}
+	var failures []string
+	for _, id := range doomed {
+		if err := r.writer.delete(id); err != nil {
+			failures = append(failures, strconv.FormatInt(id, 10)+": "+err.Error())
+			failed = append(failed, id)
+			continue
+		}
+		tflog.Info(ctx, "Deleted foreign todo", map[string]any{"ID": id})
+		deleted[id] = true
+	}
+	if len(failures) > 0 {
+		diags.AddError(
+			"Error Deleting Foreign Todos",
+			"Could not delete some foreign todos:\n\n"+strings.Join(failures, "\n"),
+		)
+	}
+
+	// Foreign todos that appeared since the plan are left for the next one
+	var listDiags diag.Diagnostics
+	switch {
+	case plan.ForeignIDs.IsUnknown():
+		remaining := []int64{}
+		for _, id := range foreign {
+			if !deleted[id] {
+				remaining = append(remaining, id)
+			}
+		}
+		plan.ForeignIDs, listDiags = types.ListValueFrom(ctx, types.Int64Type, remaining)
+		diags.Append(listDiags...)
+	case len(failed) > 0:
+		plan.ForeignIDs, listDiags = types.ListValueFrom(ctx, types.Int64Type, failed)
+		diags.Append(listDiags...)
+	}
+
+	// Planned todos that are already gone count as deleted
+	gone := []int64{}
+	for _, id := range planned {
+		if !isForeign[id] || deleted[id] {
+			gone = append(gone, id)
+		}
+	}
+	switch {
+	case len(planned) > 0:
+		plan.DeletedIDs, listDiags = types.ListValueFrom(ctx, types.Int64Type, gone)
+		diags.Append(listDiags...)
+	case plan.DeletedIDs.IsUnknown() && priorDeleted.IsNull():
+		plan.DeletedIDs = types.ListValueMust(types.Int64Type, nil)
+	case plan.DeletedIDs.IsUnknown():
+		plan.DeletedIDs = priorDeleted
+	}
+	return diags
+}
+
+// findForeign returns the IDs of the todos in scope that are not allowed, in
+// ascending order. Todos that are still being created are left out.
+func (r *todoExclusiveResource) findForeign(ctx context.Context, prefix types.String, allowed map[int64]bool) ([]int64, diag.Diagnostics) {
+	filter := todoFilter{descriptionPrefix: prefix.ValueString()}
+	todos, diags := listTodos(ctx, r.writer.client, r.writer.keyring, filter, 0)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	foreign := []int64{}
+	for _, todo := range todos {
+		if !allowed[todo.ID] {
+			foreign = append(foreign, todo.ID)
+		}
+	}
+	return foreign, diags
+}
+
+// exclusiveAllowedIDs returns the allowed IDs as a set, and whether all of
+// them are known.
+func exclusiveAllowedIDs(ctx context.Context, value types.Set) (map[int64]bool, bool, diag.Diagnostics) {
+	allowed := map[int64]bool{}
+	if value.IsNull() || value.IsUnknown() {
+		return allowed, !value.IsUnknown(), nil
+	}
+
+	var ids []types.Int64
+	diags := value.ElementsAs(ctx, &ids, false)
+	known := true
+	for _, id := range ids {
+		if id.IsUnknown() {
+			known = false
+			continue
+		}
+		allowed[id.ValueInt64()] = true
+	}
+	return allowed, known, diags
+}
+
+// parseMaxDeletions returns the max_deletions safeguard, defaulting to
+// defaultMaxDeletions.
+func parseMaxDeletions(value types.Int64) (int64, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if value.IsNull() || value.IsUnknown() {
+		return defaultMaxDeletions, diags
+	}
+	if value.ValueInt64() < 0 {
+		diags.AddAttributeError(
+			path.Root("max_deletions"),
+			"Invalid Maximum Deletions",
+			"The max_deletions must not be negative.",
+		)
+	}
+	return value.ValueInt64(), diags
+}
+
+// joinIDs formats IDs as a comma separated list.
+func joinIDs(ids []int64) string {
+	formatted := make([]string, 0, len(ids))
+	for _, id := range ids {
+		formatted = append(formatted, strconv.FormatInt(id, 10))
+	}
+	return strings.Join(formatted, ", ")
+}
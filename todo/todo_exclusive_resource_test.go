@@ -0,0 +1,94 @@
+package todo
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccTodoExclusiveResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Deletions are refused while the allowed IDs are unknown
+			{
+				Config: providerConfig + `
+resource "todo_todo" "managed" {
+	description = "[acc-exclusive] Managed"
+	completed   = false
+}
+
+resource "todo_exclusive" "test" {
+	description_prefix = "[acc-exclusive]"
+	allowed_ids        = [todo_todo.managed.id]
+}
+`,
+				ExpectError: regexp.MustCompile("Foreign Todos Not Known"),
+			},
+			// Create and Read testing
+			{
+				Config: providerConfig + `
+resource "todo_todo" "managed" {
+	description = "[acc-exclusive] Managed"
+	completed   = false
+}
+
+resource "todo_todo" "foreign" {
+	description = "[acc-exclusive] Foreign"
+	completed   = false
+}
+
+resource "todo_exclusive" "test" {
+	description_prefix = "[acc-exclusive]"
+	allowed_ids        = [todo_todo.managed.id]
+	dry_run            = true
+
+	depends_on = [todo_todo.foreign]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("todo_exclusive.test", "foreign_ids.#", "1"),
+					resource.TestCheckResourceAttrPair("todo_exclusive.test", "foreign_ids.0", "todo_todo.foreign", "id"),
+					resource.TestCheckResourceAttr("todo_exclusive.test", "deleted_ids.#", "0"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestExclusiveAllowedIDs(t *testing.T) {
+	ctx := context.Background()
+
+	allowed, known, diags := exclusiveAllowedIDs(ctx, types.SetValueMust(types.Int64Type, []attr.Value{
+		types.Int64Value(1),
+		types.Int64Value(3),
+	}))
+	if diags.HasError() || !known || !allowed[1] || !allowed[3] || allowed[2] {
+		t.Errorf("unexpected allowed IDs %v (known %t, diags %v)", allowed, known, diags)
+	}
+
+	_, known, _ = exclusiveAllowedIDs(ctx, types.SetValueMust(types.Int64Type, []attr.Value{
+		types.Int64Value(1),
+		types.Int64Unknown(),
+	}))
+	if known {
+		t.Error("expected a set with an unknown ID not to be known")
+	}
+}
+
+func TestParseMaxDeletions(t *testing.T) {
+	if max, diags := parseMaxDeletions(types.Int64Null()); max != defaultMaxDeletions || diags.HasError() {
+		t.Errorf("expected the default, got %d (%v)", max, diags)
+	}
+	if max, diags := parseMaxDeletions(types.Int64Value(0)); max != 0 || diags.HasError() {
+		t.Errorf("expected 0, got %d (%v)", max, diags)
+	}
+	if _, diags := parseMaxDeletions(types.Int64Value(-1)); !diags.HasError() {
+		t.Error("expected a negative max_deletions to be an error")
+	}
+}
@@ -0,0 +1,138 @@
+package todo
+
+import (
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const (
+	// expiryActionComplete completes a todo once it expires.
+	expiryActionComplete = "complete"
+	// expiryActionDelete deletes a todo once it expires.
+	expiryActionDelete = "delete"
+
+	// expiryMargin is how close to expiring a todo must be for a plan that
+	// changes it to leave whether it expired up to the apply, as it may
+	// expire in between.
+	expiryMargin = 10 * time.Minute
+)
+
+// validateExpiry checks the expires_at and on_expiry attributes of a todo.
+func validateExpiry(expiresAt, onExpiry types.String) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if !expiresAt.IsUnknown() && !expiresAt.IsNull() {
+		if _, err := time.Parse(time.RFC3339, expiresAt.ValueString()); err != nil {
+			diags.AddAttributeError(
+				path.Root("expires_at"),
+				"Invalid Expiry Time",
+				"The expires_at must be an RFC 3339 time such as '2024-06-30T17:00:00Z', got: '"+expiresAt.ValueString()+"'.",
+			)
+		}
+	}
+
+	if onExpiry.IsUnknown() || onExpiry.IsNull() {
+		return diags
+	}
+	switch action := onExpiry.ValueString(); action {
+	case expiryActionComplete, expiryActionDelete:
+	default:
+		diags.AddAttributeError(
+			path.Root("on_expiry"),
+			"Invalid Expiry Action",
+			"The on_expiry must be one of "+expiryActionComplete+" or "+expiryActionDelete+", got: "+action,
+		)
+	}
+	if expiresAt.IsNull() {
+		diags.AddAttributeWarning(
+			path.Root("on_expiry"),
+			"Expiry Action Without Expiry Time",
+			"The on_expiry has no effect unless expires_at is also set.",
+		)
+	}
+	return diags
+}
+
+// planExpired returns whether a todo expiring at expiresAt has expired by
+// now. A todo without an expiry time never expires.
+func planExpired(expiresAt types.String, now time.Time) types.Bool {
+	if expiresAt.IsUnknown() {
+		return types.BoolUnknown()
+	}
+	if expiresAt.IsNull() {
+		return types.BoolValue(false)
+	}
+
+	// An invalid time is reported by ValidateConfig
+	expiry, err := time.Parse(time.RFC3339, expiresAt.ValueString())
+	return types.BoolValue(err == nil && !now.Before(expiry))
+}
+
+// expiresSoon reports whether a todo expiring at expiresAt has not expired
+// by now but will within expiryMargin.
+func expiresSoon(expiresAt types.String, now time.Time) bool {
+	if expiresAt.IsUnknown() || expiresAt.IsNull() {
+		return false
+	}
+	expiry, err := time.Parse(time.RFC3339, expiresAt.ValueString())
+	return err == nil && now.Before(expiry) && !now.Add(expiryMargin).Before(expiry)
+}
+
+// expiryAction returns the on_expiry action, which defaults to completing
+// the todo.
+func expiryAction(onExpiry types.String) string {
+	if onExpiry.IsNull() || onExpiry.IsUnknown() {
+		return expiryActionComplete
+	}
+	return onExpiry.ValueString()
+}
+
+// deletedByExpiry reports whether the todo was deleted because it expired.
+func (m todoResourceModel) deletedByExpiry() bool {
+	return m.Expired.ValueBool() && expiryAction(m.OnExpiry) == expiryActionDelete
+}
+
+// completedByExpiry reports whether the todo was completed because it
+// expired.
+func (m todoResourceModel) completedByExpiry() bool {
+	return m.Expired.ValueBool() && expiryAction(m.OnExpiry) == expiryActionComplete
+}
+
+// serverCompleted returns the completed status the todo has on the server,
+// which is the configured one until it is completed by expiry.
+func (m todoResourceModel) serverCompleted() bool {
+	return m.Completed.ValueBool() || m.completedByExpiry()
+}
+
+// planServerCompleted returns the server_completed attribute planned for
+// the todo: null once it is deleted by expiry, and unknown until its
+// completed status and whether it expired are known.
+func (m todoResourceModel) planServerCompleted() types.Bool {
+	switch {
+	case m.deletedByExpiry():
+		return types.BoolNull()
+	case m.Completed.IsUnknown() || m.Expired.IsUnknown():
+		return types.BoolUnknown()
+	default:
+		return types.BoolValue(m.serverCompleted())
+	}
+}
+
+// resolveExpired decides whether the todo expired by now if the plan left
+// it to the apply, keeping the ID in prior state unless it was deleted on
+// expiry.
+func (m *todoResourceModel) resolveExpired(priorID types.Int64, now time.Time) {
+	if !m.Expired.IsUnknown() {
+		return
+	}
+	m.Expired = planExpired(m.ExpiresAt, now)
+	if m.ID.IsUnknown() {
+		m.ID = priorID
+	}
+	if m.deletedByExpiry() {
+		m.ID = types.Int64Null()
+	}
+}
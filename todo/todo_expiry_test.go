@@ -0,0 +1,112 @@
+package todo
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestPlanExpired(t *testing.T) {
+	now := time.Date(2024, 6, 30, 17, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		expiresAt types.String
+		expected  types.Bool
+	}{
+		{types.StringNull(), types.BoolValue(false)},
+		{types.StringUnknown(), types.BoolUnknown()},
+		{types.StringValue("2024-06-30T17:00:01Z"), types.BoolValue(false)},
+		{types.StringValue("2024-06-30T17:00:00Z"), types.BoolValue(true)},
+		{types.StringValue("2024-06-30T19:00:00+02:00"), types.BoolValue(true)},
+	}
+	for _, testCase := range testCases {
+		if expired := planExpired(testCase.expiresAt, now); !expired.Equal(testCase.expected) {
+			t.Errorf("expected %s for %s, got %s", testCase.expected, testCase.expiresAt, expired)
+		}
+	}
+}
+
+func TestValidateExpiry(t *testing.T) {
+	if diags := validateExpiry(types.StringValue("2024-06-30T17:00:00Z"), types.StringValue(expiryActionDelete)); diags.HasError() {
+		t.Errorf("unexpected diagnostics: %v", diags)
+	}
+	if diags := validateExpiry(types.StringValue("tomorrow"), types.StringNull()); !diags.HasError() {
+		t.Error("expected an invalid expiry time to be an error")
+	}
+	if diags := validateExpiry(types.StringValue("2024-06-30T17:00:00Z"), types.StringValue("archive")); !diags.HasError() {
+		t.Error("expected an invalid expiry action to be an error")
+	}
+	if diags := validateExpiry(types.StringNull(), types.StringValue(expiryActionComplete)); diags.HasError() || diags.WarningsCount() != 1 {
+		t.Errorf("expected a warning for an expiry action without an expiry time, got %v", diags)
+	}
+}
+
+func TestTodoResourceModelExpiry(t *testing.T) {
+	model := todoResourceModel{
+		Completed: types.BoolValue(false),
+		Expired:   types.BoolValue(true),
+		OnExpiry:  types.StringNull(),
+	}
+	if !model.completedByExpiry() || model.deletedByExpiry() || !model.serverCompleted() {
+		t.Error("expected an expired todo to be completed by default")
+	}
+
+	model.OnExpiry = types.StringValue(expiryActionDelete)
+	if model.completedByExpiry() || !model.deletedByExpiry() || model.serverCompleted() {
+		t.Error("expected an expired todo to be deleted")
+	}
+
+	model.Expired = types.BoolValue(false)
+	if model.deletedByExpiry() {
+		t.Error("expected a todo that has not expired to be kept")
+	}
+}
+
+func TestExpiresSoon(t *testing.T) {
+	now := time.Date(2024, 6, 30, 17, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		expiresAt types.String
+		expected  bool
+	}{
+		{types.StringNull(), false},
+		{types.StringUnknown(), false},
+		{types.StringValue("2024-06-30T17:00:00Z"), false},
+		{types.StringValue("2024-06-30T17:05:00Z"), true},
+		{types.StringValue("2024-06-30T17:10:00Z"), true},
+		{types.StringValue("2024-06-30T17:10:01Z"), false},
+	}
+	for _, testCase := range testCases {
+		if soon := expiresSoon(testCase.expiresAt, now); soon != testCase.expected {
+			t.Errorf("expected %v for %s, got %v", testCase.expected, testCase.expiresAt, soon)
+		}
+	}
+}
+
+func TestTodoResourceModelResolveExpired(t *testing.T) {
+	now := time.Date(2024, 6, 30, 17, 0, 0, 0, time.UTC)
+	model := todoResourceModel{
+		ID:        types.Int64Unknown(),
+		Completed: types.BoolValue(false),
+		ExpiresAt: types.StringValue("2024-06-30T16:55:00Z"),
+		OnExpiry:  types.StringValue(expiryActionDelete),
+		Expired:   types.BoolUnknown(),
+	}
+	if served := model.planServerCompleted(); !served.IsUnknown() {
+		t.Errorf("expected the server status to be unknown until the expiry is, got %s", served)
+	}
+
+	model.resolveExpired(types.Int64Value(7), now)
+	if !model.Expired.ValueBool() || !model.ID.IsNull() || !model.planServerCompleted().IsNull() {
+		t.Errorf("expected the todo to be deleted on expiry, got %+v", model)
+	}
+
+	model.ID = types.Int64Unknown()
+	model.ExpiresAt = types.StringValue("2024-06-30T17:05:00Z")
+	model.Expired = types.BoolUnknown()
+	model.resolveExpired(types.Int64Value(7), now)
+	if model.Expired.ValueBool() || model.ID.ValueInt64() != 7 || !model.planServerCompleted().Equal(types.BoolValue(false)) {
+		t.Errorf("expected the todo to be kept, got %+v", model)
+	}
+}
@@ -0,0 +1,344 @@
+package todo
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+
+	// Todo API Libraries
+	"github.com/spkane/todo-for-terraform/client"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	// exportFormatMarkdown renders a GitHub-flavored Markdown task list.
+	exportFormatMarkdown = "markdown"
+	// exportFormatCSV renders CSV with an id,description,completed header.
+	exportFormatCSV = "csv"
+	// exportFormatJSON renders a JSON array of todos.
+	exportFormatJSON = "json"
+	// exportFormatTodoTxt renders todo.txt lines, completed ones prefixed with x.
+	exportFormatTodoTxt = "todotxt"
+
+	// exportSortID sorts exported todos by ID.
+	exportSortID = "id"
+	// exportSortDescription sorts exported todos by description.
+	exportSortDescription = "description"
+	// exportSortCompleted sorts open todos before completed ones.
+	exportSortCompleted = "completed"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &todoExportDataSource{}
+	_ datasource.DataSourceWithConfigure = &todoExportDataSource{}
+)
+
+// NewTodoExportDataSource is a helper function to simplify the provider implementation.
+func NewTodoExportDataSource() datasource.DataSource {
+	return &todoExportDataSource{}
+}
+
+// todoExportDataSource is the data source implementation.
+type todoExportDataSource struct {
+	client  *client.TodoList
+	keyring *todoKeyring
+}
+
+// todoExportDataSourceModel maps the data source schema data.
+type todoExportDataSourceModel struct {
+	Filter         *todoFilterModel `tfsdk:"filter"`
+	MaxResults     types.Int64      `tfsdk:"max_results"`
+	Format         types.String     `tfsdk:"format"`
+	SortBy         types.String     `tfsdk:"sort_by"`
+	SortDescending types.Bool       `tfsdk:"sort_descending"`
+	Header         types.String     `tfsdk:"header"`
+	Content        types.String     `tfsdk:"content"`
+}
+
+// todoExport holds the rendering options of the todo_export data source.
+type todoExport struct {
+	format         string
+	sortBy         string
+	sortDescending bool
+	header         *template.Template
+}
+
+// exportHeaderData is the data available to header templates.
+type exportHeaderData struct {
+	Total     int
+	Completed int
+	Open      int
+}
+
+// newTodoExport validates the rendering options of a todo_export config.
+func newTodoExport(model todoExportDataSourceModel) (todoExport, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	export := todoExport{
+		format:         model.Format.ValueString(),
+		sortBy:         model.SortBy.ValueString(),
+		sortDescending: model.SortDescending.ValueBool(),
+	}
+	if export.format == "" {
+		export.format = exportFormatMarkdown
+	}
+	if export.sortBy == "" {
+		export.sortBy = exportSortID
+	}
+
+	switch export.format {
+	case exportFormatMarkdown, exportFormatTodoTxt, exportFormatCSV, exportFormatJSON:
+	default:
+		diags.AddAttributeError(
+			path.Root("format"),
+			"Invalid Export Format",
+			"The format must be one of "+exportFormatMarkdown+", "+exportFormatCSV+", "+exportFormatJSON+" or "+exportFormatTodoTxt+", got: "+export.format,
+		)
+	}
+
+	switch export.sortBy {
+	case exportSortID, exportSortDescription, exportSortCompleted:
+	default:
+		diags.AddAttributeError(
+			path.Root("sort_by"),
+			"Invalid Export Sort",
+			"The sort_by must be one of "+exportSortID+", "+exportSortDescription+" or "+exportSortCompleted+", got: "+export.sortBy,
+		)
+	}
+
+	if !model.Header.IsNull() {
+		if export.format == exportFormatCSV || export.format == exportFormatJSON {
+			diags.AddAttributeError(
+				path.Root("header"),
+				"Invalid Export Header",
+				"A header can only be used with the "+exportFormatMarkdown+" and "+exportFormatTodoTxt+" formats, "+
+					"since it would make "+export.format+" output invalid.",
+			)
+		}
+
+		header, err := template.New("header").Option("missingkey=error").Parse(model.Header.ValueString())
+		if err != nil {
+			diags.AddAttributeError(
+				path.Root("header"),
+				"Invalid Export Header",
+				"The header is not a valid template: "+err.Error(),
+			)
+		}
+		export.header = header
+	}
+	return export, diags
+}
+
+// sort orders todos by the export's sort key, then by ID. Sorting in
+// descending order only reverses the sort key, so ties stay in ascending ID
+// order.
+func (e todoExport) sort(todos []todoItem) {
+	before := func(a, b todoItem) bool {
+		switch e.sortBy {
+		case exportSortDescription:
+			return a.Description < b.Description
+		case exportSortCompleted:
+			return !a.Completed && b.Completed
+		}
+		return a.ID < b.ID
+	}
+
+	sort.SliceStable(todos, func(i, j int) bool {
+		a, b := todos[i], todos[j]
+		if e.sortDescending {
+			a, b = b, a
+		}
+		if before(a, b) || before(b, a) {
+			return before(a, b)
+		}
+		return todos[i].ID < todos[j].ID
+	})
+}
+
+// render sorts the todos and renders them in the export's format.
+func (e todoExport) render(todos []todoItem) (string, error) {
+	e.sort(todos)
+
+	var buf bytes.Buffer
+	if e.header != nil {
+		data := exportHeaderData{Total: len(todos)}
+		for _, todo := range todos {
+			if todo.Completed {
+				data.Completed++
+			}
+		}
+		data.Open = data.Total - data.Completed
+
+		if err := e.header.Execute(&buf, data); err != nil {
+			return "", err
+		}
+		if buf.Len() > 0 && !bytes.HasSuffix(buf.Bytes(), []byte("\n")) {
+			buf.WriteString("\n")
+		}
+	}
+
+	switch e.format {
+	case exportFormatMarkdown:
+		for _, todo := range todos {
+			box := "[ ]"
+			if todo.Completed {
+				box = "[x]"
+			}
+			buf.WriteString("- " + box + " " + singleLine(todo.Description) + "\n")
+		}
+	case exportFormatTodoTxt:
+		for _, todo := range todos {
+			description := singleLine(todo.Description)
+			switch {
+			case todo.Completed:
+				buf.WriteString("x ")
+			case strings.HasPrefix(description, "x "):
+				// Keep todo.txt readers from taking it for a completed todo
+				buf.WriteString(" ")
+			}
+			buf.WriteString(description + "\n")
+		}
+	case exportFormatCSV:
+		w := csv.NewWriter(&buf)
+		records := [][]string{{"id", "description", "completed"}}
+		for _, todo := range todos {
+			records = append(records, []string{
+				strconv.FormatInt(todo.ID, 10),
+				todo.Description,
+				strconv.FormatBool(todo.Completed),
+			})
+		}
+		if err := w.WriteAll(records); err != nil {
+			return "", err
+		}
+	case exportFormatJSON:
+		type exportedTodo struct {
+			ID          int64  `json:"id"`
+			Description string `json:"description"`
+			Completed   bool   `json:"completed"`
+		}
+		exported := make([]exportedTodo, 0, len(todos))
+		for _, todo := range todos {
+			exported = append(exported, exportedTodo(todo))
+		}
+		data, err := json.MarshalIndent(exported, "", "  ")
+		if err != nil {
+			return "", err
+		}
+		buf.Write(data)
+		buf.WriteString("\n")
+	}
+	return buf.String(), nil
+}
+
+// singleLine joins the lines of a description, since line based formats
+// have one todo per line.
+func singleLine(description string) string {
+	return strings.Join(strings.Fields(strings.ReplaceAll(description, "\r\n", "\n")), " ")
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *todoExportDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData := req.ProviderData.(*todoProviderData)
+	d.client = providerData.client
+	d.keyring = providerData.keyring
+}
+
+// Metadata returns the data source type name.
+func (d *todoExportDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_export"
+}
+
+// Schema defines the schema for the data source.
+func (d *todoExportDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Render todos as a Markdown task list, CSV, JSON or todo.txt, for example to write them to a file with `local_file`.",
+		Attributes: map[string]schema.Attribute{
+			"filter": todoFilterDataSourceAttribute(),
+			"max_results": schema.Int64Attribute{
+				Description: "Fail instead of rendering more than this many todos (default: 1000).",
+				Optional:    true,
+			},
+			"format": schema.StringAttribute{
+				Description: "The output format: `markdown` for a GitHub-flavored task list, `csv`, `json`, or `todotxt` " +
+					"for todo.txt lines with completed todos prefixed by `x` and open todos that start with `x ` indented by a space " +
+					"(default: `markdown`).",
+				Optional: true,
+			},
+			"sort_by": schema.StringAttribute{
+				Description: "Order todos by `id`, `description`, or `completed` to list open todos first (default: `id`). Ties are ordered by ascending ID, also when sorting in descending order.",
+				Optional:    true,
+			},
+			"sort_descending": schema.BoolAttribute{
+				Description: "Reverse the sort order (default: false).",
+				Optional:    true,
+			},
+			"header": schema.StringAttribute{
+				Description: "A Go template rendered above the todos, for the `markdown` and `todotxt` formats only. " +
+					"It can use `{{ .Total }}`, `{{ .Completed }}` and `{{ .Open }}`, the number of exported todos in each state.",
+				Optional: true,
+			},
+			"content": schema.StringAttribute{
+				Description: "The rendered todos.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *todoExportDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	tflog.Debug(ctx, "Preparing to read export data source")
+	var state todoExportDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	filter, diags := newTodoFilter(state.Filter, path.Root("filter"))
+	resp.Diagnostics.Append(diags...)
+	export, diags := newTodoExport(state)
+	resp.Diagnostics.Append(diags...)
+	maxResults, diags := parseMaxResults(state.MaxResults)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	matched, diags := listTodos(ctx, d.client, d.keyring, filter, maxResults)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	content, err := export.render(matched)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Render Todos",
+			err.Error(),
+		)
+		return
+	}
+	state.Content = types.StringValue(content)
+
+	// Set state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	tflog.Debug(ctx, "Finished reading export data source", map[string]any{"count": len(matched)})
+}
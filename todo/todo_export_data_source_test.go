@@ -0,0 +1,178 @@
+package todo
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccTodoExportDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: providerConfig + `
+resource "todo_todo" "open" {
+	description = "[acc-export] Go Shopping"
+	completed = false
+}
+
+resource "todo_todo" "done" {
+	description = "[acc-export] Walk the dog"
+	completed = true
+}
+
+data "todo_export" "test" {
+	filter = {
+		description_prefix = "[acc-export]"
+	}
+	sort_by = "completed"
+	header  = "# Chores ({{ .Completed }}/{{ .Total }})"
+
+	depends_on = [todo_todo.open, todo_todo.done]
+}
+`,
+				Check: resource.TestCheckResourceAttr("data.todo_export.test", "content",
+					"# Chores (1/2)\n- [ ] [acc-export] Go Shopping\n- [x] [acc-export] Walk the dog\n"),
+			},
+		},
+	})
+}
+
+func TestTodoExportRender(t *testing.T) {
+	todos := []todoItem{
+		{ID: 2, Description: "Walk the dog", Completed: true},
+		{ID: 1, Description: "Go \"Shopping\"\nfor shoes", Completed: false},
+	}
+
+	testCases := map[string]struct {
+		model    todoExportDataSourceModel
+		expected string
+	}{
+		"markdown": {
+			model:    todoExportDataSourceModel{},
+			expected: "- [ ] Go \"Shopping\" for shoes\n- [x] Walk the dog\n",
+		},
+		"todotxt": {
+			model: todoExportDataSourceModel{
+				Format: types.StringValue("todotxt"),
+				Header: types.StringValue("# {{ .Open }} open"),
+			},
+			expected: "# 1 open\nGo \"Shopping\" for shoes\nx Walk the dog\n",
+		},
+		"csv": {
+			model: todoExportDataSourceModel{
+				Format:         types.StringValue("csv"),
+				SortDescending: types.BoolValue(true),
+			},
+			expected: "id,description,completed\n2,Walk the dog,true\n1,\"Go \"\"Shopping\"\"\nfor shoes\",false\n",
+		},
+		"json": {
+			model: todoExportDataSourceModel{
+				Format: types.StringValue("json"),
+				SortBy: types.StringValue("description"),
+			},
+			expected: "[\n" +
+				"  {\n    \"id\": 1,\n    \"description\": \"Go \\\"Shopping\\\"\\nfor shoes\",\n    \"completed\": false\n  },\n" +
+				"  {\n    \"id\": 2,\n    \"description\": \"Walk the dog\",\n    \"completed\": true\n  }\n" +
+				"]\n",
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+		t.Run(name, func(t *testing.T) {
+			export, diags := newTodoExport(testCase.model)
+			if diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+
+			got, err := export.render(append([]todoItem(nil), todos...))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != testCase.expected {
+				t.Errorf("expected:\n%s\ngot:\n%s", testCase.expected, got)
+			}
+		})
+	}
+}
+
+func TestNewTodoExportInvalid(t *testing.T) {
+	for name, model := range map[string]todoExportDataSourceModel{
+		"format":      {Format: types.StringValue("yaml")},
+		"sort":        {SortBy: types.StringValue("priority")},
+		"json-header": {Format: types.StringValue("json"), Header: types.StringValue("# Todos")},
+		"template":    {Header: types.StringValue("{{ .Total")},
+	} {
+		if _, diags := newTodoExport(model); !diags.HasError() {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestTodoExportRenderTodoTxtEscapesOpenTodos(t *testing.T) {
+	export, diags := newTodoExport(todoExportDataSourceModel{Format: types.StringValue("todotxt")})
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	got, err := export.render([]todoItem{
+		{ID: 1, Description: "x marks the spot", Completed: false},
+		{ID: 2, Description: "x marks the spot", Completed: true},
+		{ID: 3, Description: "xylophone lessons", Completed: false},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := " x marks the spot\nx x marks the spot\nxylophone lessons\n"
+	if got != expected {
+		t.Errorf("expected:\n%q\ngot:\n%q", expected, got)
+	}
+}
+
+func TestTodoExportSortDescendingKeepsTies(t *testing.T) {
+	todos := []todoItem{
+		{ID: 1, Description: "Walk the dog", Completed: true},
+		{ID: 2, Description: "Go Shopping", Completed: false},
+		{ID: 3, Description: "Feed the cat", Completed: true},
+		{ID: 4, Description: "Water the plants", Completed: false},
+	}
+
+	testCases := map[string]struct {
+		model    todoExportDataSourceModel
+		expected []int64
+	}{
+		"completed": {
+			model:    todoExportDataSourceModel{SortBy: types.StringValue("completed"), SortDescending: types.BoolValue(true)},
+			expected: []int64{1, 3, 2, 4},
+		},
+		"id": {
+			model:    todoExportDataSourceModel{SortDescending: types.BoolValue(true)},
+			expected: []int64{4, 3, 2, 1},
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+		t.Run(name, func(t *testing.T) {
+			export, diags := newTodoExport(testCase.model)
+			if diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+
+			sorted := append([]todoItem(nil), todos...)
+			export.sort(sorted)
+			got := make([]int64, 0, len(sorted))
+			for _, todo := range sorted {
+				got = append(got, todo.ID)
+			}
+			if !reflect.DeepEqual(got, testCase.expected) {
+				t.Errorf("expected %v, got %v", testCase.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,315 @@
+package todo
+
+import (
+	"context"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	// Todo API Libraries
+	"github.com/spkane/todo-for-terraform/client"
+	"github.com/spkane/todo-for-terraform/models"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	resourceschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// defaultMaxResults caps how many todos a data source returns by default.
+const defaultMaxResults = 1000
+
+// todoFilterModel maps the filter attribute shared by data sources that
+// list todos.
+type todoFilterModel struct {
+	Completed           types.Bool   `tfsdk:"completed"`
+	DescriptionContains types.String `tfsdk:"description_contains"`
+	DescriptionPrefix   types.String `tfsdk:"description_prefix"`
+	DescriptionRegex    types.String `tfsdk:"description_regex"`
+	IDMin               types.Int64  `tfsdk:"id_min"`
+	IDMax               types.Int64  `tfsdk:"id_max"`
+}
+
+// todoFilterDataSourceAttribute returns the schema of the filter attribute
+// for data sources.
+func todoFilterDataSourceAttribute() schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		Description: "Only include todos matching every given condition.",
+		Optional:    true,
+		Attributes: map[string]schema.Attribute{
+			"completed": schema.BoolAttribute{
+				Description: "Only include todos with this completed status.",
+				Optional:    true,
+			},
+			"description_contains": schema.StringAttribute{
+				Description: "Only include todos whose description contains this text.",
+				Optional:    true,
+			},
+			"description_prefix": schema.StringAttribute{
+				Description: "Only include todos whose description starts with this text.",
+				Optional:    true,
+			},
+			"description_regex": schema.StringAttribute{
+				Description: "Only include todos whose description matches this RE2 regular expression.",
+				Optional:    true,
+			},
+			"id_min": schema.Int64Attribute{
+				Description: "Only include todos with an ID of at least this value.",
+				Optional:    true,
+			},
+			"id_max": schema.Int64Attribute{
+				Description: "Only include todos with an ID of at most this value.",
+				Optional:    true,
+			},
+		},
+	}
+}
+
+// todoFilterResourceAttribute returns the schema of the filter attribute
+// for resources that act on the matching todos once. Changing the filter
+// replaces the resource.
+func todoFilterResourceAttribute() resourceschema.SingleNestedAttribute {
+	dataSourceAttributes := todoFilterDataSourceAttribute().Attributes
+	attributes := make(map[string]resourceschema.Attribute, len(dataSourceAttributes))
+	for name, attribute := range dataSourceAttributes {
+		switch attribute := attribute.(type) {
+		case schema.BoolAttribute:
+			attributes[name] = resourceschema.BoolAttribute{Description: attribute.Description, Optional: true}
+		case schema.StringAttribute:
+			attributes[name] = resourceschema.StringAttribute{Description: attribute.Description, Optional: true}
+		case schema.Int64Attribute:
+			attributes[name] = resourceschema.Int64Attribute{Description: attribute.Description, Optional: true}
+		}
+	}
+
+	return resourceschema.SingleNestedAttribute{
+		Description: "Only act on todos matching every given condition.",
+		Optional:    true,
+		Attributes:  attributes,
+		PlanModifiers: []planmodifier.Object{
+			objectplanmodifier.RequiresReplace(),
+		},
+	}
+}
+
+// isUnknown reports whether any condition of the filter is unknown.
+func (m *todoFilterModel) isUnknown() bool {
+	return m.Completed.IsUnknown() || m.DescriptionContains.IsUnknown() || m.DescriptionPrefix.IsUnknown() ||
+		m.DescriptionRegex.IsUnknown() || m.IDMin.IsUnknown() || m.IDMax.IsUnknown()
+}
+
+// todoFilter selects todos by their plaintext description, completed status
+// and ID. The zero value matches every todo.
+type todoFilter struct {
+	completed           *bool
+	descriptionEquals   *string
+	descriptionContains string
+	descriptionPrefix   string
+	descriptionRegex    *regexp.Regexp
+	idMin               *int64
+	idMax               *int64
+}
+
+// newTodoFilter builds a todoFilter from a filter attribute at the given path.
+// A nil model matches every todo.
+func newTodoFilter(model *todoFilterModel, attributePath path.Path) (todoFilter, diag.Diagnostics) {
+	var filter todoFilter
+	var diags diag.Diagnostics
+
+	if model == nil {
+		return filter, diags
+	}
+
+	if !model.Completed.IsNull() {
+		completed := model.Completed.ValueBool()
+		filter.completed = &completed
+	}
+	filter.descriptionContains = model.DescriptionContains.ValueString()
+	filter.descriptionPrefix = model.DescriptionPrefix.ValueString()
+	if !model.DescriptionRegex.IsNull() {
+		re, err := regexp.Compile(model.DescriptionRegex.ValueString())
+		if err != nil {
+			diags.AddAttributeError(
+				attributePath.AtName("description_regex"),
+				"Invalid Description Regex",
+				"The description_regex is not a valid regular expression: "+err.Error(),
+			)
+		}
+		filter.descriptionRegex = re
+	}
+	if !model.IDMin.IsNull() {
+		idMin := model.IDMin.ValueInt64()
+		filter.idMin = &idMin
+	}
+	if !model.IDMax.IsNull() {
+		idMax := model.IDMax.ValueInt64()
+		filter.idMax = &idMax
+	}
+	if filter.idMin != nil && filter.idMax != nil && *filter.idMin > *filter.idMax {
+		diags.AddAttributeError(
+			attributePath.AtName("id_max"),
+			"Invalid ID Range",
+			"The id_max ("+strconv.FormatInt(*filter.idMax, 10)+") must not be less than the id_min ("+strconv.FormatInt(*filter.idMin, 10)+").",
+		)
+	}
+	return filter, diags
+}
+
+// since returns the FindTodos since parameter that skips todos below idMin.
+func (f todoFilter) since() int64 {
+	if f.idMin == nil || *f.idMin <= 1 {
+		return 0
+	}
+	return *f.idMin - 1
+}
+
+// matches reports whether a todo passes the filter.
+func (f todoFilter) matches(todo todoItem) bool {
+	if f.completed != nil && todo.Completed != *f.completed {
+		return false
+	}
+	if f.descriptionEquals != nil && todo.Description != *f.descriptionEquals {
+		return false
+	}
+	if f.descriptionContains != "" && !strings.Contains(todo.Description, f.descriptionContains) {
+		return false
+	}
+	if f.descriptionPrefix != "" && !strings.HasPrefix(todo.Description, f.descriptionPrefix) {
+		return false
+	}
+	if f.descriptionRegex != nil && !f.descriptionRegex.MatchString(todo.Description) {
+		return false
+	}
+	if f.idMin != nil && todo.ID < *f.idMin {
+		return false
+	}
+	if f.idMax != nil && todo.ID > *f.idMax {
+		return false
+	}
+	return true
+}
+
+// todoItem is a todo read from the server with its plaintext description.
+type todoItem struct {
+	ID          int64
+	Description string
+	Completed   bool
+}
+
+// todoItemModel maps a todo in the list attributes of data sources.
+type todoItemModel struct {
+	ID          types.Int64  `tfsdk:"id"`
+	Description types.String `tfsdk:"description"`
+	Completed   types.Bool   `tfsdk:"completed"`
+}
+
+// todoItemDataSourceAttributes returns the schema of a todo in the list
+// attributes of data sources.
+func todoItemDataSourceAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"id": schema.Int64Attribute{
+			Description: "The unique identifier for the todo.",
+			Computed:    true,
+		},
+		"description": schema.StringAttribute{
+			Description: "The description for the todo.",
+			Computed:    true,
+		},
+		"completed": schema.BoolAttribute{
+			Description: "The completed status for the todo.",
+			Computed:    true,
+		},
+	}
+}
+
+// parseMaxResults returns the max_results cap, defaulting to
+// defaultMaxResults.
+func parseMaxResults(value types.Int64) (int64, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if value.IsNull() {
+		return defaultMaxResults, diags
+	}
+	if value.ValueInt64() < 1 {
+		diags.AddAttributeError(
+			path.Root("max_results"),
+			"Invalid Maximum Results",
+			"The max_results must be at least 1.",
+		)
+	}
+	return value.ValueInt64(), diags
+}
+
+// listTodos pages through the todos on the server and returns the ones that
+// pass the filter, with decrypted descriptions. Todos that are still being
+// created, or were orphaned by a failed create, and the markers publishing
+// revision logs are left out. Todos whose description cannot be decrypted
+// with the keyring are left out with a warning, so one todo written with a
+// lost key does not break every list. Todos are returned in ascending ID
+// order.
+//
+// If maxResults is positive, listing stops with an error diagnostic once
+// more than maxResults todos match.
+func listTodos(ctx context.Context, c *client.TodoList, keyring *todoKeyring, filter todoFilter, maxResults int64) ([]todoItem, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	matched := []todoItem{}
+	var undecryptable []string
+	err := forEachTodo(ctx, c, filter.since(), func(item *models.Item) bool {
+		if item.Description == nil || item.Completed == nil {
+			return true
+		}
+		if _, token, _ := parsePendingMarker(*item.Description); token != "" {
+			return true
+		}
+		if _, _, ok := parseRevisionMarker(*item.Description); ok {
+			return true
+		}
+
+		description, decryptDiags := keyring.decryptDescription(item.ID, *item.Description)
+		if decryptDiags.HasError() {
+			undecryptable = append(undecryptable, strconv.FormatInt(item.ID, 10))
+			return true
+		}
+
+		todo := todoItem{
+			ID:          item.ID,
+			Description: description,
+			Completed:   *item.Completed,
+		}
+		if !filter.matches(todo) {
+			return true
+		}
+
+		if maxResults > 0 && int64(len(matched)) >= maxResults {
+			diags.AddError(
+				"Too Many Todos",
+				"More than "+strconv.FormatInt(maxResults, 10)+" todos match. Narrow the filter or raise max_results.",
+			)
+			return false
+		}
+		matched = append(matched, todo)
+		return true
+	})
+	if err != nil {
+		diags.AddError(
+			"Unable to Read Todos",
+			err.Error(),
+		)
+	}
+
+	if len(undecryptable) > 0 {
+		diags.AddWarning(
+			"Skipped Undecryptable Todos",
+			"The descriptions of todo IDs "+strings.Join(undecryptable, ", ")+" could not be decrypted, so they were left out. "+
+				"Ensure the key they were encrypted with is set as the provider encryption_key or listed in previous_encryption_keys.",
+		)
+	}
+
+	sort.Slice(matched, func(i, j int) bool { return matched[i].ID < matched[j].ID })
+	return matched, diags
+}
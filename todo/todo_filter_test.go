@@ -0,0 +1,127 @@
+package todo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestTodoFilter(t *testing.T) {
+	todos := []todoItem{
+		{ID: 1, Description: "[sprint-42] Go Shopping", Completed: false},
+		{ID: 2, Description: "[sprint-42] Walk the dog", Completed: true},
+		{ID: 3, Description: "[sprint-43] Go Shopping", Completed: false},
+	}
+
+	testCases := map[string]struct {
+		model    *todoFilterModel
+		expected []int64
+	}{
+		"none": {
+			model:    nil,
+			expected: []int64{1, 2, 3},
+		},
+		"completed": {
+			model:    &todoFilterModel{Completed: types.BoolValue(false)},
+			expected: []int64{1, 3},
+		},
+		"contains": {
+			model:    &todoFilterModel{DescriptionContains: types.StringValue("Shopping")},
+			expected: []int64{1, 3},
+		},
+		"prefix": {
+			model:    &todoFilterModel{DescriptionPrefix: types.StringValue("[sprint-42]")},
+			expected: []int64{1, 2},
+		},
+		"regex": {
+			model:    &todoFilterModel{DescriptionRegex: types.StringValue(`^\[sprint-4\d\] Walk`)},
+			expected: []int64{2},
+		},
+		"id-range": {
+			model:    &todoFilterModel{IDMin: types.Int64Value(2), IDMax: types.Int64Value(3)},
+			expected: []int64{2, 3},
+		},
+		"combined": {
+			model: &todoFilterModel{
+				Completed:         types.BoolValue(false),
+				DescriptionPrefix: types.StringValue("[sprint-42]"),
+			},
+			expected: []int64{1},
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+		t.Run(name, func(t *testing.T) {
+			filter, diags := newTodoFilter(testCase.model, path.Root("filter"))
+			if diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+
+			var got []int64
+			for _, todo := range todos {
+				if filter.matches(todo) {
+					got = append(got, todo.ID)
+				}
+			}
+			if len(got) != len(testCase.expected) {
+				t.Fatalf("expected %v, got %v", testCase.expected, got)
+			}
+			for i := range got {
+				if got[i] != testCase.expected[i] {
+					t.Fatalf("expected %v, got %v", testCase.expected, got)
+				}
+			}
+		})
+	}
+}
+
+func TestNewTodoFilterInvalid(t *testing.T) {
+	if _, diags := newTodoFilter(&todoFilterModel{DescriptionRegex: types.StringValue("(")}, path.Root("filter")); !diags.HasError() {
+		t.Errorf("expected an error for an invalid regex")
+	}
+	if _, diags := newTodoFilter(&todoFilterModel{IDMin: types.Int64Value(5), IDMax: types.Int64Value(4)}, path.Root("filter")); !diags.HasError() {
+		t.Errorf("expected an error for an empty ID range")
+	}
+
+	filter, _ := newTodoFilter(&todoFilterModel{IDMin: types.Int64Value(5)}, path.Root("filter"))
+	if filter.since() != 4 {
+		t.Errorf("expected since 4, got %d", filter.since())
+	}
+}
+
+func TestListTodosSkipsUndecryptable(t *testing.T) {
+	previous, err := newTodoKeyring(testPreviousEncryptionKey, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	lost, err := previous.encrypt("Walk the dog")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	keyring, err := newTodoKeyring(testEncryptionKey, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	encrypted, err := keyring.encrypt("Go Shopping")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	_, c := newFakeTodoServer(t, map[int64]map[string]any{
+		1: {"id": 1, "description": encrypted, "completed": false},
+		2: {"id": 2, "description": lost, "completed": false},
+	})
+
+	todos, diags := listTodos(context.Background(), c, keyring, todoFilter{}, 0)
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if len(todos) != 1 || todos[0].ID != 1 || todos[0].Description != "Go Shopping" {
+		t.Errorf("expected only the decryptable todo, got %+v", todos)
+	}
+	if diags.WarningsCount() != 1 {
+		t.Errorf("expected a warning for the skipped todo, got %v", diags)
+	}
+}
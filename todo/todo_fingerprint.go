@@ -0,0 +1,135 @@
+package todo
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+
+	"github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+const (
+	// fingerprintPrivateKey is the private state key holding a todoFingerprint.
+	fingerprintPrivateKey = "fingerprint"
+
+	// idReuseActionRemove drops a replaced todo from state so it is recreated.
+	idReuseActionRemove = "remove"
+	// idReuseActionError fails the refresh when a replaced todo is found.
+	idReuseActionError = "error"
+)
+
+// privateStateGetter is satisfied by the private state on resource requests.
+type privateStateGetter interface {
+	GetKey(ctx context.Context, key string) ([]byte, diag.Diagnostics)
+}
+
+// privateStateSetter is satisfied by the private state on resource responses.
+type privateStateSetter interface {
+	SetKey(ctx context.Context, key string, value []byte) diag.Diagnostics
+}
+
+// todoFingerprint records the identity of a todo written by the resource.
+//
+// Every create gets its own creation run, which the resource also publishes
+// to the Todo server with the todo's revision log. A todo whose revision
+// marker is gone, or names another creation run, is an unrelated todo the
+// server handed our ID to (e.g. after a restart of the in-memory server),
+// while a todo whose description was edited outside Terraform still has its
+// marker and is refreshed like any other drift.
+//
+// Todos without a published marker, created by older provider versions or
+// when publishing failed, can only be recognised by the hash of their
+// description, so for them an edit outside Terraform looks like ID reuse.
+type todoFingerprint struct {
+	DescriptionHash string `json:"description_hash"`
+	// Normalization is the description_normalization mode the hash was
+	// taken under. Older fingerprints always ignored case.
+	Normalization string `json:"normalization,omitempty"`
+	CreationRun   string `json:"creation_run"`
+	CreatedAt     string `json:"created_at"`
+}
+
+// newTodoFingerprint returns a fingerprint for a freshly created todo.
+func newTodoFingerprint(description string, normalizer *descriptionNormalizer) (*todoFingerprint, error) {
+	run, err := uuid.GenerateUUID()
+	if err != nil {
+		return nil, err
+	}
+
+	fingerprint := &todoFingerprint{
+		CreationRun: run,
+		CreatedAt:   time.Now().UTC().Format(time.RFC3339),
+	}
+	return fingerprint.withDescription(description, normalizer), nil
+}
+
+// withDescription returns a copy of the fingerprint for an updated
+// description, keeping the original creation run.
+func (f todoFingerprint) withDescription(description string, normalizer *descriptionNormalizer) *todoFingerprint {
+	f.Normalization = descriptionNormalizationExact
+	if normalizer != nil && normalizer.mode != "" {
+		f.Normalization = normalizer.mode
+	}
+	f.DescriptionHash = hashDescription(description, f.normalization())
+	return &f
+}
+
+// matches reports whether the plaintext description read from the server
+// still hashes like the one the fingerprint was taken from.
+func (f todoFingerprint) matches(description string) bool {
+	return f.DescriptionHash == hashDescription(description, f.normalization())
+}
+
+// normalization returns the normalization mode the hash was taken under.
+func (f todoFingerprint) normalization() string {
+	if f.Normalization == "" {
+		return descriptionNormalizationCaseInsensitive
+	}
+	return f.Normalization
+}
+
+// hashDescription returns the hex encoded SHA-256 of a todo description,
+// normalized under the given mode so the server re-normalizing text is not
+// mistaken for an unrelated todo.
+func hashDescription(description, mode string) string {
+	sum := sha256.Sum256([]byte(normalizeDescription(description, mode)))
+	return hex.EncodeToString(sum[:])
+}
+
+// getTodoFingerprint loads the fingerprint from private state. A nil
+// fingerprint is returned for imported todos and state written by older
+// provider versions.
+func getTodoFingerprint(ctx context.Context, private privateStateGetter) (*todoFingerprint, diag.Diagnostics) {
+	data, diags := private.GetKey(ctx, fingerprintPrivateKey)
+	if diags.HasError() || len(data) == 0 {
+		return nil, diags
+	}
+
+	var fingerprint todoFingerprint
+	if err := json.Unmarshal(data, &fingerprint); err != nil {
+		diags.AddError(
+			"Error Reading Todo Fingerprint",
+			"Could not decode the todo fingerprint stored in private state: "+err.Error(),
+		)
+		return nil, diags
+	}
+	return &fingerprint, diags
+}
+
+// setTodoFingerprint stores the fingerprint in private state.
+func setTodoFingerprint(ctx context.Context, private privateStateSetter, fingerprint *todoFingerprint) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	data, err := json.Marshal(fingerprint)
+	if err != nil {
+		diags.AddError(
+			"Error Saving Todo Fingerprint",
+			"Could not encode the todo fingerprint for private state: "+err.Error(),
+		)
+		return diags
+	}
+	return private.SetKey(ctx, fingerprintPrivateKey, data)
+}
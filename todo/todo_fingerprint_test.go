@@ -0,0 +1,41 @@
+package todo
+
+import (
+	"testing"
+)
+
+func TestTodoFingerprint(t *testing.T) {
+	fingerprint, err := newTodoFingerprint("Go Shopping", &descriptionNormalizer{mode: descriptionNormalizationCaseInsensitive})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if !fingerprint.matches("Go Shopping") {
+		t.Errorf("expected fingerprint to match the original todo")
+	}
+	if !fingerprint.matches("go shopping ") {
+		t.Errorf("expected fingerprint to match the original todo after server normalization")
+	}
+	if fingerprint.matches("Walk the dog") {
+		t.Errorf("expected fingerprint not to match an unrelated todo")
+	}
+
+	updated := fingerprint.withDescription("Walk the dog", nil)
+	if updated.CreationRun != fingerprint.CreationRun {
+		t.Errorf("expected creation run %q to be kept, got %q", fingerprint.CreationRun, updated.CreationRun)
+	}
+	if !updated.matches("Walk the dog") {
+		t.Errorf("expected updated fingerprint to match the new description")
+	}
+	if updated.matches("walk the dog") {
+		t.Errorf("expected updated fingerprint to compare exactly without a normalizer")
+	}
+}
+
+func TestTodoFingerprintLegacyNormalization(t *testing.T) {
+	// Fingerprints taken before the mode was recorded ignored case
+	fingerprint := todoFingerprint{DescriptionHash: hashDescription("go shopping", descriptionNormalizationCaseInsensitive)}
+	if !fingerprint.matches("Go Shopping") {
+		t.Errorf("expected legacy fingerprint to ignore case")
+	}
+}
@@ -0,0 +1,197 @@
+package todo
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	// icalendarProductID identifies the provider in rendered calendars.
+	icalendarProductID = "-//spkane//terraform-provider-todo//EN"
+
+	// icalendarLineLimit is the longest content line, in octets, before it
+	// is folded.
+	icalendarLineLimit = 75
+
+	// icalendarStatusCompleted and icalendarStatusNeedsAction are the VTODO
+	// statuses of completed and open todos.
+	icalendarStatusCompleted   = "COMPLETED"
+	icalendarStatusNeedsAction = "NEEDS-ACTION"
+)
+
+// icalendarTodoUID returns the UID of the VTODO rendered for a todo.
+func icalendarTodoUID(id int64, domain string) string {
+	return "todo-" + strconv.FormatInt(id, 10) + "@" + domain
+}
+
+// renderICalendar renders todos as a VCALENDAR of VTODO components, stamped
+// with the given time, with CRLF line endings and folded long lines.
+func renderICalendar(todos []todoItem, domain, name string, stamp time.Time) string {
+	var b strings.Builder
+	line := func(content string) {
+		b.WriteString(foldICalendarLine(content))
+		b.WriteString("\r\n")
+	}
+
+	line("BEGIN:VCALENDAR")
+	line("VERSION:2.0")
+	line("PRODID:" + icalendarProductID)
+	if name != "" {
+		line("X-WR-CALNAME:" + escapeICalendarText(name))
+	}
+	for _, todo := range todos {
+		line("BEGIN:VTODO")
+		line("UID:" + icalendarTodoUID(todo.ID, domain))
+		line("DTSTAMP:" + stamp.UTC().Format("20060102T150405Z"))
+		line("SUMMARY:" + escapeICalendarText(todo.Description))
+		if todo.Completed {
+			line("STATUS:" + icalendarStatusCompleted)
+			line("PERCENT-COMPLETE:100")
+		} else {
+			line("STATUS:" + icalendarStatusNeedsAction)
+		}
+		line("END:VTODO")
+	}
+	line("END:VCALENDAR")
+	return b.String()
+}
+
+// icalendarTodo is a VTODO component parsed from an iCalendar document.
+type icalendarTodo struct {
+	UID       string
+	Summary   string
+	Completed bool
+}
+
+// parseICalendarTodos returns the VTODO components of an iCalendar document
+// in order. Every VTODO needs a unique UID and a SUMMARY. Components nested
+// in a VTODO, such as VALARM, are ignored.
+func parseICalendarTodos(content string) ([]icalendarTodo, error) {
+	var todos []icalendarTodo
+	var current *icalendarTodo
+	var currentLine int
+	nested := 0
+	uids := make(map[string]bool)
+
+	for _, contentLine := range unfoldICalendarLines(content) {
+		name, value, ok := splitICalendarLine(contentLine.text)
+		if !ok {
+			continue
+		}
+
+		switch {
+		case name == "BEGIN" && strings.EqualFold(value, "VTODO") && current == nil:
+			current = &icalendarTodo{}
+			currentLine = contentLine.number
+		case current == nil:
+		case name == "BEGIN":
+			nested++
+		case name == "END" && nested > 0:
+			nested--
+		case nested > 0:
+		case name == "END" && strings.EqualFold(value, "VTODO"):
+			switch {
+			case current.UID == "":
+				return nil, fmt.Errorf("the VTODO on line %d has no UID", currentLine)
+			case current.Summary == "":
+				return nil, fmt.Errorf("the VTODO %q on line %d has no SUMMARY", current.UID, currentLine)
+			case uids[current.UID]:
+				return nil, fmt.Errorf("the UID %q on line %d is used by more than one VTODO", current.UID, currentLine)
+			}
+			uids[current.UID] = true
+			todos = append(todos, *current)
+			current = nil
+		case name == "UID":
+			current.UID = value
+		case name == "SUMMARY":
+			current.Summary = unescapeICalendarText(value)
+		case name == "STATUS":
+			current.Completed = strings.EqualFold(value, icalendarStatusCompleted)
+		}
+	}
+	if current != nil {
+		return nil, fmt.Errorf("the VTODO on line %d has no END:VTODO", currentLine)
+	}
+	return todos, nil
+}
+
+// icalendarLine is an unfolded content line and the line it started on.
+type icalendarLine struct {
+	number int
+	text   string
+}
+
+// unfoldICalendarLines splits an iCalendar document into content lines,
+// joining folded lines, which continue with a leading space or tab.
+func unfoldICalendarLines(content string) []icalendarLine {
+	var lines []icalendarLine
+	for i, raw := range strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n") {
+		if (strings.HasPrefix(raw, " ") || strings.HasPrefix(raw, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1].text += raw[1:]
+			continue
+		}
+		lines = append(lines, icalendarLine{number: i + 1, text: raw})
+	}
+	return lines
+}
+
+// splitICalendarLine splits a content line into its upper case property name
+// and its value, dropping any property parameters.
+func splitICalendarLine(line string) (string, string, bool) {
+	quoted := false
+	for i, r := range line {
+		switch {
+		case r == '"':
+			quoted = !quoted
+		case r == ':' && !quoted:
+			name, _, _ := strings.Cut(line[:i], ";")
+			return strings.ToUpper(strings.TrimSpace(name)), line[i+1:], true
+		}
+	}
+	return "", "", false
+}
+
+// foldICalendarLine folds a content line longer than icalendarLineLimit
+// octets, without splitting UTF-8 characters.
+func foldICalendarLine(line string) string {
+	var b strings.Builder
+	limit := icalendarLineLimit
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		b.WriteString(line[:cut])
+		b.WriteString("\r\n ")
+		line = line[cut:]
+		// Continuation lines start with a space, which counts to the limit
+		limit = icalendarLineLimit - 1
+	}
+	b.WriteString(line)
+	return b.String()
+}
+
+// escapeICalendarText escapes a TEXT property value.
+func escapeICalendarText(text string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+	).Replace(text)
+}
+
+// unescapeICalendarText reverses escapeICalendarText.
+func unescapeICalendarText(text string) string {
+	return strings.NewReplacer(
+		`\\`, `\`,
+		`\;`, ";",
+		`\,`, ",",
+		`\n`, "\n",
+		`\N`, "\n",
+	).Replace(text)
+}
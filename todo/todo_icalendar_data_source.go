@@ -0,0 +1,148 @@
+package todo
+
+import (
+	"context"
+	"time"
+
+	// Todo API Libraries
+	"github.com/spkane/todo-for-terraform/client"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// defaultICalendarUIDDomain is the domain of rendered VTODO UIDs by default.
+const defaultICalendarUIDDomain = "terraform-provider-todo"
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &todoICalendarDataSource{}
+	_ datasource.DataSourceWithConfigure = &todoICalendarDataSource{}
+)
+
+// NewTodoICalendarDataSource is a helper function to simplify the provider implementation.
+func NewTodoICalendarDataSource() datasource.DataSource {
+	return &todoICalendarDataSource{}
+}
+
+// todoICalendarDataSource is the data source implementation.
+type todoICalendarDataSource struct {
+	client  *client.TodoList
+	keyring *todoKeyring
+}
+
+// todoICalendarDataSourceModel maps the data source schema data.
+type todoICalendarDataSourceModel struct {
+	Filter       *todoFilterModel `tfsdk:"filter"`
+	MaxResults   types.Int64      `tfsdk:"max_results"`
+	UIDDomain    types.String     `tfsdk:"uid_domain"`
+	CalendarName types.String     `tfsdk:"calendar_name"`
+	DTStamp      types.String     `tfsdk:"dtstamp"`
+	Content      types.String     `tfsdk:"content"`
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *todoICalendarDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData := req.ProviderData.(*todoProviderData)
+	d.client = providerData.client
+	d.keyring = providerData.keyring
+}
+
+// Metadata returns the data source type name.
+func (d *todoICalendarDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_icalendar"
+}
+
+// Schema defines the schema for the data source.
+func (d *todoICalendarDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Render todos as an iCalendar (RFC 5545) document with a VTODO for each todo, for example to write an `.ics` file with `local_file`.",
+		Attributes: map[string]schema.Attribute{
+			"filter": todoFilterDataSourceAttribute(),
+			"max_results": schema.Int64Attribute{
+				Description: "Fail instead of rendering more than this many todos (default: 1000).",
+				Optional:    true,
+			},
+			"uid_domain": schema.StringAttribute{
+				Description: "The domain of the VTODO UIDs, which are `todo-<id>@<uid_domain>` (default: `" + defaultICalendarUIDDomain + "`).",
+				Optional:    true,
+			},
+			"calendar_name": schema.StringAttribute{
+				Description: "A calendar name, rendered as the `X-WR-CALNAME` property.",
+				Optional:    true,
+			},
+			"dtstamp": schema.StringAttribute{
+				Description: "The RFC 3339 time of the VTODO `DTSTAMP` properties (default: the time the data source is read). " +
+					"Set it to keep the content stable between reads.",
+				Optional: true,
+			},
+			"content": schema.StringAttribute{
+				Description: "The rendered iCalendar document, with CRLF line endings.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *todoICalendarDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	tflog.Debug(ctx, "Preparing to read icalendar data source")
+	var state todoICalendarDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	filter, diags := newTodoFilter(state.Filter, path.Root("filter"))
+	resp.Diagnostics.Append(diags...)
+	maxResults, diags := parseMaxResults(state.MaxResults)
+	resp.Diagnostics.Append(diags...)
+
+	domain := defaultICalendarUIDDomain
+	if !state.UIDDomain.IsNull() {
+		domain = state.UIDDomain.ValueString()
+		if domain == "" {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("uid_domain"),
+				"Invalid UID Domain",
+				"The uid_domain must not be empty.",
+			)
+		}
+	}
+
+	stamp := time.Now()
+	if !state.DTStamp.IsNull() {
+		parsed, err := time.Parse(time.RFC3339, state.DTStamp.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("dtstamp"),
+				"Invalid DTSTAMP",
+				"The dtstamp must be an RFC 3339 time: "+err.Error(),
+			)
+		}
+		stamp = parsed
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	matched, diags := listTodos(ctx, d.client, d.keyring, filter, maxResults)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state.Content = types.StringValue(renderICalendar(matched, domain, state.CalendarName.ValueString(), stamp))
+
+	// Set state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	tflog.Debug(ctx, "Finished reading icalendar data source", map[string]any{"count": len(matched)})
+}
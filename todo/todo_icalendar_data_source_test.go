@@ -0,0 +1,43 @@
+package todo
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccTodoICalendarDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: providerConfig + `
+resource "todo_todo" "test" {
+	description = "[acc-icalendar] Walk the dog"
+	completed = true
+}
+
+data "todo_icalendar" "test" {
+	filter = {
+		description_prefix = "[acc-icalendar]"
+	}
+	uid_domain    = "example.com"
+	calendar_name = "Chores"
+	dtstamp       = "2024-03-01T12:30:00Z"
+
+	depends_on = [todo_todo.test]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestMatchResourceAttr("data.todo_icalendar.test", "content",
+						regexp.MustCompile(`^BEGIN:VCALENDAR\r\n(?:.*\r\n)*X-WR-CALNAME:Chores\r\n`)),
+					resource.TestMatchResourceAttr("data.todo_icalendar.test", "content",
+						regexp.MustCompile(`\r\nBEGIN:VTODO\r\nUID:todo-\d+@example\.com\r\nDTSTAMP:20240301T123000Z\r\n`+
+							`SUMMARY:\[acc-icalendar\] Walk the dog\r\nSTATUS:COMPLETED\r\n`)),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,399 @@
+package todo
+
+import (
+	"context"
+
+	"github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource               = &todoICalendarImportResource{}
+	_ resource.ResourceWithConfigure  = &todoICalendarImportResource{}
+	_ resource.ResourceWithModifyPlan = &todoICalendarImportResource{}
+)
+
+// icalendarImportItemType is the object type of an entry in the items
+// attribute.
+var icalendarImportItemType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"uid":         types.StringType,
+		"description": types.StringType,
+		"completed":   types.BoolType,
+		"id":          types.Int64Type,
+	},
+}
+
+// NewTodoICalendarImportResource is a helper function to simplify the provider implementation.
+func NewTodoICalendarImportResource() resource.Resource {
+	return &todoICalendarImportResource{}
+}
+
+// todoICalendarImportResource is the resource implementation.
+type todoICalendarImportResource struct {
+	writer *todoWriter
+}
+
+// todoICalendarImportResourceModel maps the resource schema data.
+type todoICalendarImportResourceModel struct {
+	ID      types.String `tfsdk:"id"`
+	Content types.String `tfsdk:"content"`
+	Items   types.List   `tfsdk:"items"`
+	UIDMap  types.Map    `tfsdk:"uid_map"`
+}
+
+// icalendarImportItemModel maps an entry in the items attribute.
+type icalendarImportItemModel struct {
+	UID         types.String `tfsdk:"uid"`
+	Description types.String `tfsdk:"description"`
+	Completed   types.Bool   `tfsdk:"completed"`
+	ID          types.Int64  `tfsdk:"id"`
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *todoICalendarImportResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	r.writer = newTodoWriter(req.ProviderData.(*todoProviderData))
+}
+
+// Metadata returns the resource type name.
+func (r *todoICalendarImportResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_icalendar_import"
+}
+
+// Schema defines the schema for the resource.
+func (r *todoICalendarImportResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Keep a todo for every VTODO of an iCalendar (RFC 5545) document. The SUMMARY of a VTODO is the todo " +
+			"description and a STATUS of COMPLETED completes it. VTODOs are matched to todos by UID, so editing a VTODO " +
+			"updates its todo, while adding or removing one creates or deletes a todo.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "A unique identifier for the import.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"content": schema.StringAttribute{
+				Description: "The iCalendar document to import, for example from `file()`. Every VTODO needs a unique UID and a SUMMARY; " +
+					"other components, such as VEVENT, are ignored.",
+				Required: true,
+			},
+			"items": schema.ListNestedAttribute{
+				Description: "The imported VTODOs, in the order they appear in the document.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"uid": schema.StringAttribute{
+							Description: "The UID of the VTODO.",
+							Computed:    true,
+						},
+						"description": schema.StringAttribute{
+							Description: "The SUMMARY of the VTODO, used as the todo description.",
+							Computed:    true,
+						},
+						"completed": schema.BoolAttribute{
+							Description: "Whether the VTODO has a STATUS of COMPLETED.",
+							Computed:    true,
+						},
+						"id": schema.Int64Attribute{
+							Description: "The unique identifier of the VTODO's todo.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"uid_map": schema.MapAttribute{
+				Description: "The unique identifiers of the imported todos, keyed by VTODO UID.",
+				Computed:    true,
+				ElementType: types.Int64Type,
+			},
+		},
+	}
+}
+
+// ModifyPlan plans the items parsed from the document, keeping the IDs of
+// UIDs that already have a todo.
+func (r *todoICalendarImportResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to plan when the resource is being destroyed
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan todoICalendarImportResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() || plan.Content.IsUnknown() {
+		return
+	}
+
+	vtodos, err := parseICalendarTodos(plan.Content.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("content"),
+			"Invalid iCalendar Document",
+			err.Error(),
+		)
+		return
+	}
+
+	existing := map[string]int64{}
+	if !req.State.Raw.IsNull() {
+		var state todoICalendarImportResourceModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		items, diags := icalendarImportItems(ctx, state.Items)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		for _, item := range items {
+			existing[item.UID.ValueString()] = item.ID.ValueInt64()
+		}
+	}
+
+	items := make([]icalendarImportItemModel, 0, len(vtodos))
+	for _, vtodo := range vtodos {
+		id := types.Int64Unknown()
+		if existingID, ok := existing[vtodo.UID]; ok {
+			id = types.Int64Value(existingID)
+		}
+		items = append(items, icalendarImportItemModel{
+			UID:         types.StringValue(vtodo.UID),
+			Description: types.StringValue(vtodo.Summary),
+			Completed:   types.BoolValue(vtodo.Completed),
+			ID:          id,
+		})
+	}
+
+	resp.Diagnostics.Append(plan.setItems(ctx, items)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, plan)...)
+}
+
+// Create creates a todo for every VTODO.
+func (r *todoICalendarImportResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	tflog.Debug(ctx, "Preparing to create todo icalendar import resource")
+	// Retrieve values from plan
+	var plan todoICalendarImportResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id, err := uuid.GenerateUUID()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Creating iCalendar Import",
+			"Could not generate an ID, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	plan.ID = types.StringValue(id)
+
+	resp.Diagnostics.Append(r.sync(ctx, &plan, nil)...)
+
+	// Save the todos that were created even if others failed
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+	tflog.Debug(ctx, "Created todo icalendar import resource", map[string]any{"success": !resp.Diagnostics.HasError()})
+}
+
+// Read refreshes the imported todos, dropping the ones deleted from the
+// server so they are planned to be created again.
+func (r *todoICalendarImportResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	tflog.Debug(ctx, "Preparing to read todo icalendar import resource")
+	// Get current state
+	var state todoICalendarImportResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	items, diags := icalendarImportItems(ctx, state.Items)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	entries, err := r.writer.refresh(ctx, icalendarImportEntries(items))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Todos",
+			"Could not read icalendar import todos: "+err.Error(),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(state.setItems(ctx, icalendarImportItemsFromEntries(entries))...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	tflog.Debug(ctx, "Finished reading todo icalendar import resource", map[string]any{"success": true})
+}
+
+// Update creates, updates and deletes todos to match the VTODOs.
+func (r *todoICalendarImportResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	tflog.Debug(ctx, "Preparing to update todo icalendar import resource")
+	// Retrieve values from plan
+	var plan todoICalendarImportResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Retrieve the todos that exist from state
+	var state todoICalendarImportResourceModel
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	existing, diags := icalendarImportItems(ctx, state.Items)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.sync(ctx, &plan, existing)...)
+
+	// Save the changes that were made even if others failed
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+	tflog.Debug(ctx, "Updated todo icalendar import resource", map[string]any{"success": !resp.Diagnostics.HasError()})
+}
+
+// Delete deletes every imported todo.
+func (r *todoICalendarImportResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	tflog.Debug(ctx, "Preparing to delete todo icalendar import resource")
+	// Retrieve values from state
+	var state todoICalendarImportResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	items, diags := icalendarImportItems(ctx, state.Items)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for _, item := range items {
+		if err := r.writer.delete(item.ID.ValueInt64()); err != nil {
+			resp.Diagnostics.AddError(
+				"Error Deleting Todo",
+				"Could not delete todo ID "+item.ID.String()+", unexpected error: "+err.Error(),
+			)
+		}
+	}
+	tflog.Debug(ctx, "Deleted todo icalendar import resource", map[string]any{"success": !resp.Diagnostics.HasError()})
+}
+
+// sync makes the todos on the server match the VTODOs in plan, given the
+// items that already have todos, leaving plan with the items that have todos
+// afterwards.
+func (r *todoICalendarImportResource) sync(ctx context.Context, plan *todoICalendarImportResourceModel, existing []icalendarImportItemModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	vtodos, err := parseICalendarTodos(plan.Content.ValueString())
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root("content"),
+			"Invalid iCalendar Document",
+			err.Error(),
+		)
+		return diags
+	}
+
+	targets := make([]todoSyncTarget, 0, len(vtodos))
+	for _, vtodo := range vtodos {
+		targets = append(targets, todoSyncTarget{
+			Key:         vtodo.UID,
+			Description: vtodo.Summary,
+			Completed:   vtodo.Completed,
+		})
+	}
+
+	entries, syncDiags := r.writer.sync(ctx, targets, icalendarImportEntries(existing), 1, false)
+	diags.Append(syncDiags...)
+
+	diags.Append(plan.setItems(ctx, icalendarImportItemsFromEntries(entries))...)
+	return diags
+}
+
+// icalendarImportEntries converts items to sync entries keyed by UID.
+func icalendarImportEntries(items []icalendarImportItemModel) []todoSyncEntry {
+	entries := make([]todoSyncEntry, 0, len(items))
+	for _, item := range items {
+		entries = append(entries, todoSyncEntry{
+			Key:         item.UID.ValueString(),
+			ID:          item.ID.ValueInt64(),
+			Description: item.Description.ValueString(),
+			Completed:   item.Completed.ValueBool(),
+		})
+	}
+	return entries
+}
+
+// icalendarImportItemsFromEntries converts sync entries to items.
+func icalendarImportItemsFromEntries(entries []todoSyncEntry) []icalendarImportItemModel {
+	items := make([]icalendarImportItemModel, 0, len(entries))
+	for _, entry := range entries {
+		items = append(items, icalendarImportItemModel{
+			UID:         types.StringValue(entry.Key),
+			Description: types.StringValue(entry.Description),
+			Completed:   types.BoolValue(entry.Completed),
+			ID:          types.Int64Value(entry.ID),
+		})
+	}
+	return items
+}
+
+// icalendarImportItems converts the items attribute to its entries. Null and
+// unknown lists have no entries.
+func icalendarImportItems(ctx context.Context, list types.List) ([]icalendarImportItemModel, diag.Diagnostics) {
+	var items []icalendarImportItemModel
+	if list.IsNull() || list.IsUnknown() {
+		return items, nil
+	}
+	diags := list.ElementsAs(ctx, &items, false)
+	return items, diags
+}
+
+// setItems sets the items and uid_map attributes from the item entries.
+func (m *todoICalendarImportResourceModel) setItems(ctx context.Context, items []icalendarImportItemModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	uids := make(map[string]attr.Value, len(items))
+	for _, item := range items {
+		uids[item.UID.ValueString()] = item.ID
+	}
+
+	list, listDiags := types.ListValueFrom(ctx, icalendarImportItemType, items)
+	diags.Append(listDiags...)
+	uidMap, mapDiags := types.MapValue(types.Int64Type, uids)
+	diags.Append(mapDiags...)
+	m.Items = list
+	m.UIDMap = uidMap
+	return diags
+}
@@ -0,0 +1,67 @@
+package todo
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccTodoICalendarImportResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: providerConfig + `
+resource "todo_icalendar_import" "test" {
+	content = <<-EOT
+		BEGIN:VCALENDAR
+		BEGIN:VTODO
+		UID:renew@example.com
+		SUMMARY:Renew the certificate
+		STATUS:NEEDS-ACTION
+		END:VTODO
+		BEGIN:VTODO
+		UID:backup@example.com
+		SUMMARY:Check the backups
+		STATUS:COMPLETED
+		END:VTODO
+		END:VCALENDAR
+	EOT
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("todo_icalendar_import.test", "items.#", "2"),
+					resource.TestCheckResourceAttr("todo_icalendar_import.test", "items.0.uid", "renew@example.com"),
+					resource.TestCheckResourceAttr("todo_icalendar_import.test", "items.0.description", "Renew the certificate"),
+					resource.TestCheckResourceAttr("todo_icalendar_import.test", "items.0.completed", "false"),
+					resource.TestCheckResourceAttr("todo_icalendar_import.test", "items.1.completed", "true"),
+					resource.TestCheckResourceAttrSet("todo_icalendar_import.test", "uid_map.renew@example.com"),
+					resource.TestCheckResourceAttrSet("todo_icalendar_import.test", "id"),
+				),
+			},
+			// Update and Read testing
+			{
+				Config: providerConfig + `
+resource "todo_icalendar_import" "test" {
+	content = <<-EOT
+		BEGIN:VCALENDAR
+		BEGIN:VTODO
+		UID:renew@example.com
+		SUMMARY:Renew the certificate
+		STATUS:COMPLETED
+		END:VTODO
+		END:VCALENDAR
+	EOT
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("todo_icalendar_import.test", "items.#", "1"),
+					resource.TestCheckResourceAttr("todo_icalendar_import.test", "items.0.completed", "true"),
+					resource.TestCheckNoResourceAttr("todo_icalendar_import.test", "uid_map.backup@example.com"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
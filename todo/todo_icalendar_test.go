@@ -0,0 +1,108 @@
+package todo
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRenderICalendar(t *testing.T) {
+	todos := []todoItem{
+		{ID: 1, Description: "Go Shopping; milk, eggs", Completed: false},
+		{ID: 2, Description: "Walk the dog", Completed: true},
+	}
+	stamp := time.Date(2024, 3, 1, 12, 30, 0, 0, time.UTC)
+
+	expected := "BEGIN:VCALENDAR\r\n" +
+		"VERSION:2.0\r\n" +
+		"PRODID:-//spkane//terraform-provider-todo//EN\r\n" +
+		"X-WR-CALNAME:Chores\r\n" +
+		"BEGIN:VTODO\r\n" +
+		"UID:todo-1@example.com\r\n" +
+		"DTSTAMP:20240301T123000Z\r\n" +
+		"SUMMARY:Go Shopping\\; milk\\, eggs\r\n" +
+		"STATUS:NEEDS-ACTION\r\n" +
+		"END:VTODO\r\n" +
+		"BEGIN:VTODO\r\n" +
+		"UID:todo-2@example.com\r\n" +
+		"DTSTAMP:20240301T123000Z\r\n" +
+		"SUMMARY:Walk the dog\r\n" +
+		"STATUS:COMPLETED\r\n" +
+		"PERCENT-COMPLETE:100\r\n" +
+		"END:VTODO\r\n" +
+		"END:VCALENDAR\r\n"
+
+	if got := renderICalendar(todos, "example.com", "Chores", stamp); got != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, got)
+	}
+}
+
+func TestICalendarRoundTrip(t *testing.T) {
+	todos := []todoItem{
+		{ID: 7, Description: strings.Repeat("Größe, ", 30) + "\nsecond line", Completed: true},
+	}
+
+	content := renderICalendar(todos, "example.com", "", time.Now())
+	for _, line := range strings.Split(content, "\r\n") {
+		if len(line) > icalendarLineLimit {
+			t.Errorf("line longer than %d octets: %q", icalendarLineLimit, line)
+		}
+	}
+
+	parsed, err := parseICalendarTodos(content)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []icalendarTodo{{UID: "todo-7@example.com", Summary: todos[0].Description, Completed: true}}
+	if !reflect.DeepEqual(parsed, expected) {
+		t.Errorf("expected %+v, got %+v", expected, parsed)
+	}
+}
+
+func TestParseICalendarTodos(t *testing.T) {
+	content := "BEGIN:VCALENDAR\n" +
+		"BEGIN:VEVENT\n" +
+		"UID:event@example.com\n" +
+		"SUMMARY:Not a todo\n" +
+		"END:VEVENT\n" +
+		"BEGIN:VTODO\n" +
+		"UID:a@example.com\n" +
+		"SUMMARY;LANGUAGE=en:Renew the \n" +
+		" certificate\n" +
+		"BEGIN:VALARM\n" +
+		"STATUS:COMPLETED\n" +
+		"END:VALARM\n" +
+		"END:VTODO\n" +
+		"BEGIN:VTODO\n" +
+		"UID:b@example.com\n" +
+		"SUMMARY:Done\n" +
+		"STATUS:COMPLETED\n" +
+		"END:VTODO\n" +
+		"END:VCALENDAR\n"
+
+	todos, err := parseICalendarTodos(content)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []icalendarTodo{
+		{UID: "a@example.com", Summary: "Renew the certificate", Completed: false},
+		{UID: "b@example.com", Summary: "Done", Completed: true},
+	}
+	if !reflect.DeepEqual(todos, expected) {
+		t.Errorf("expected %+v, got %+v", expected, todos)
+	}
+}
+
+func TestParseICalendarTodosInvalid(t *testing.T) {
+	for name, content := range map[string]string{
+		"no-uid":     "BEGIN:VTODO\nSUMMARY:A\nEND:VTODO\n",
+		"no-summary": "BEGIN:VTODO\nUID:a\nEND:VTODO\n",
+		"duplicate":  "BEGIN:VTODO\nUID:a\nSUMMARY:A\nEND:VTODO\nBEGIN:VTODO\nUID:a\nSUMMARY:B\nEND:VTODO\n",
+		"unfinished": "BEGIN:VTODO\nUID:a\nSUMMARY:A\n",
+	} {
+		if _, err := parseICalendarTodos(content); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
@@ -0,0 +1,151 @@
+package todo
+
+import (
+	"context"
+	"regexp"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-uuid"
+
+	// Todo API Libraries
+	"github.com/spkane/todo-for-terraform/client"
+	"github.com/spkane/todo-for-terraform/models"
+)
+
+const (
+	// createAttempts is the number of times AddOne is tried for a single todo.
+	createAttempts = 3
+
+	// orphanAdoptionMinAge is how long ago a create must have begun before
+	// another create adopts the todo it left behind, so creates that are
+	// still running are left alone.
+	orphanAdoptionMinAge = time.Minute
+)
+
+// pendingMarkerPattern matches the marker appended to the description of a
+// todo while it is being created. The marker carries the idempotency token,
+// which starts with the Unix time the create began.
+//
+// The marker is removed again as soon as the create knows the new todo's ID,
+// so a todo that still carries one is an orphan left behind by a create
+// whose response was lost.
+var pendingMarkerPattern = regexp.MustCompile(` \[todo-pending:((\d+)-[0-9a-f-]+)\]$`)
+
+// newIdempotencyToken returns a token for a single todo create.
+func newIdempotencyToken() (string, error) {
+	id, err := uuid.GenerateUUID()
+	if err != nil {
+		return "", err
+	}
+	return strconv.FormatInt(time.Now().Unix(), 10) + "-" + id, nil
+}
+
+// withPendingMarker appends the pending marker for token to a description.
+func withPendingMarker(description, token string) string {
+	return description + " [todo-pending:" + token + "]"
+}
+
+// parsePendingMarker splits a description into the description without its
+// pending marker, the idempotency token and the time the create began. The
+// token is empty if the description has no marker.
+func parsePendingMarker(description string) (string, string, time.Time) {
+	match := pendingMarkerPattern.FindStringSubmatchIndex(description)
+	if match == nil {
+		return description, "", time.Time{}
+	}
+
+	token := description[match[2]:match[3]]
+	started, err := strconv.ParseInt(description[match[4]:match[5]], 10, 64)
+	if err != nil {
+		return description, "", time.Time{}
+	}
+	return description[:match[0]], token, time.Unix(started, 0).UTC()
+}
+
+// findPendingTodo looks for the todo created with the idempotency token.
+// A nil todo is returned if there is none.
+func findPendingTodo(ctx context.Context, c *client.TodoList, token string) (*models.Item, error) {
+	var found *models.Item
+	err := forEachTodo(ctx, c, 0, func(item *models.Item) bool {
+		if item.Description == nil {
+			return true
+		}
+		if _, itemToken, _ := parsePendingMarker(*item.Description); itemToken == token {
+			found = item
+			return false
+		}
+		return true
+	})
+	return found, err
+}
+
+// orphanAdopter hands out the todos left behind by earlier creates whose
+// response was lost. The server is scanned for orphans once, on the first
+// create, rather than before every create, and each orphan is handed out at
+// most once.
+type orphanAdopter struct {
+	mu      sync.Mutex
+	scanned bool
+	orphans []todoOrphan
+}
+
+// adopt looks for a todo left behind by an earlier create of the same todo:
+// an orphan from a create that began more than orphanAdoptionMinAge ago,
+// with the given completed status and a stored description, without its
+// marker, that satisfies matches. The first one found is claimed and
+// returned, or nil if there is none.
+func (a *orphanAdopter) adopt(ctx context.Context, c *client.TodoList, completed bool, matches func(stored string) bool) (*models.Item, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if !a.scanned {
+		orphans, err := findOrphanTodos(ctx, c, orphanAdoptionMinAge)
+		if err != nil {
+			return nil, err
+		}
+		a.orphans, a.scanned = orphans, true
+	}
+
+	for i, orphan := range a.orphans {
+		if orphan.item.Completed == nil || *orphan.item.Completed != completed || !matches(orphan.description) {
+			continue
+		}
+		a.orphans = append(a.orphans[:i], a.orphans[i+1:]...)
+		return orphan.item, nil
+	}
+	return nil, nil
+}
+
+// todoOrphan is a todo left behind by a failed create.
+type todoOrphan struct {
+	item        *models.Item
+	description string
+	token       string
+	started     time.Time
+}
+
+// findOrphanTodos returns the todos that still carry a pending marker from a
+// create that began longer than minAge ago.
+func findOrphanTodos(ctx context.Context, c *client.TodoList, minAge time.Duration) ([]todoOrphan, error) {
+	cutoff := time.Now().Add(-minAge)
+
+	var orphans []todoOrphan
+	err := forEachTodo(ctx, c, 0, func(item *models.Item) bool {
+		if item.Description == nil {
+			return true
+		}
+		description, token, started := parsePendingMarker(*item.Description)
+		if token != "" && started.Before(cutoff) {
+			orphans = append(orphans, todoOrphan{
+				item:        item,
+				description: description,
+				token:       token,
+				started:     started,
+			})
+		}
+		return true
+	})
+	return orphans, err
+}
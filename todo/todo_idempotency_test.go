@@ -0,0 +1,85 @@
+package todo
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPendingMarker(t *testing.T) {
+	token, err := newIdempotencyToken()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	description, gotToken, started := parsePendingMarker(withPendingMarker("Go Shopping", token))
+	if description != "Go Shopping" {
+		t.Errorf("expected description without marker, got %q", description)
+	}
+	if gotToken != token {
+		t.Errorf("expected token %q, got %q", token, gotToken)
+	}
+	if time.Since(started) > time.Minute {
+		t.Errorf("expected the create to have started just now, got %s", started)
+	}
+
+	encrypted := encryptedDescriptionPrefix + "abcd1234:a_b-c:d_e-f"
+	description, gotToken, _ = parsePendingMarker(withPendingMarker(encrypted, token))
+	if description != encrypted || gotToken != token {
+		t.Errorf("expected marker to be split from an encrypted description, got %q, %q", description, gotToken)
+	}
+
+	for _, plain := range []string{"Go Shopping", "Go Shopping [todo-pending:soon]", "[todo-pending:" + token + "] Go Shopping"} {
+		description, gotToken, _ := parsePendingMarker(plain)
+		if description != plain || gotToken != "" {
+			t.Errorf("expected %q to have no marker, got %q, %q", plain, description, gotToken)
+		}
+	}
+
+	if !strings.HasPrefix(token, "1") && !strings.HasPrefix(token, "2") {
+		t.Errorf("expected token to start with a Unix time, got %q", token)
+	}
+}
+
+func TestTodoWriterCreateAdoptsOrphan(t *testing.T) {
+	started := strconv.FormatInt(time.Now().Add(-time.Hour).Unix(), 10)
+	old := withPendingMarker("Go Shopping", started+"-00000000-0000-0000-0000-000000000101")
+	fresh, err := newIdempotencyToken()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	server, c := newFakeTodoServer(t, map[int64]map[string]any{
+		101: {"id": 101, "description": old, "completed": false},
+		102: {"id": 102, "description": old, "completed": true},
+		103: {"id": 103, "description": withPendingMarker("Walk the dog", started+"-00000000-0000-0000-0000-000000000103"), "completed": false},
+		104: {"id": 104, "description": withPendingMarker("Go Shopping", fresh), "completed": false},
+	})
+	lists := 0
+	server.onList = func(map[int64]map[string]any) { lists++ }
+	w := &todoWriter{client: c}
+
+	todo, err := w.create(context.Background(), "Go Shopping", false)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if todo.ID != 101 || server.get(101)["description"] != "Go Shopping" {
+		t.Errorf("expected the orphan to be adopted and finished, got %+v and %v", todo, server.get(101))
+	}
+
+	// The orphan is only adopted once, and orphans of creates that are still
+	// running or of other todos are left alone
+	todo, err = w.create(context.Background(), "Go Shopping", false)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if todo.ID != 105 {
+		t.Errorf("expected a new todo to be created, got %+v", todo)
+	}
+
+	// The server is only scanned for orphans by the first create
+	if lists != 1 {
+		t.Errorf("expected the todos to be listed once, got %d", lists)
+	}
+}
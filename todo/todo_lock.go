@@ -0,0 +1,180 @@
+package todo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"time"
+
+	// Todo API Libraries
+	"github.com/spkane/todo-for-terraform/models"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// lockNamePattern matches valid lock names.
+var lockNamePattern = regexp.MustCompile(`^[A-Za-z0-9_.-]+$`)
+
+// lockMarkerPattern matches the description of a lock marker todo: the lock
+// name followed by the holder metadata as JSON. Markers are stored in
+// plaintext, so every pipeline sharing the server sees them whatever its
+// encryption keys.
+var lockMarkerPattern = regexp.MustCompile(`^\[todo-lock:([A-Za-z0-9_.-]+)\] (\{.*\})$`)
+
+// todoLockHolder is the metadata stored in a lock marker todo.
+type todoLockHolder struct {
+	Holder     string    `json:"holder"`
+	AcquiredAt time.Time `json:"acquired_at"`
+}
+
+// todoLockMarker is a marker todo of a lock.
+type todoLockMarker struct {
+	ID     int64
+	Holder todoLockHolder
+}
+
+// lockMarker returns the description of a marker todo for lock name.
+func lockMarker(name string, holder todoLockHolder) (string, error) {
+	metadata, err := json.Marshal(holder)
+	if err != nil {
+		return "", err
+	}
+	return "[todo-lock:" + name + "] " + string(metadata), nil
+}
+
+// parseLockMarker returns the lock name and holder metadata of a marker
+// todo description, and false if it is not a marker. A marker that is still
+// being created counts, since it is already in the race for the lock.
+func parseLockMarker(description string) (string, todoLockHolder, bool) {
+	var holder todoLockHolder
+
+	description, _, _ = parsePendingMarker(description)
+	match := lockMarkerPattern.FindStringSubmatch(description)
+	if match == nil {
+		return "", holder, false
+	}
+	if err := json.Unmarshal([]byte(match[2]), &holder); err != nil {
+		return "", holder, false
+	}
+	return match[1], holder, true
+}
+
+// findLockMarkers returns the marker todos of lock name in ascending ID
+// order.
+func (w *todoWriter) findLockMarkers(ctx context.Context, name string) ([]todoLockMarker, error) {
+	var markers []todoLockMarker
+	err := forEachTodo(ctx, w.client, 0, func(item *models.Item) bool {
+		if item.Description == nil {
+			return true
+		}
+		if markerName, holder, ok := parseLockMarker(*item.Description); ok && markerName == name {
+			markers = append(markers, todoLockMarker{ID: item.ID, Holder: holder})
+		}
+		return true
+	})
+	sort.Slice(markers, func(i, j int) bool { return markers[i].ID < markers[j].ID })
+	return markers, err
+}
+
+// acquireLock creates a marker todo for lock name and waits until it is the
+// marker with the lowest ID, polling every pollInterval. Markers acquired
+// longer than ttl ago are deleted as stale, unless ttl is zero. If the lock
+// is not acquired within timeout, the marker is deleted again, and if
+// another holder deletes it while waiting, acquiring fails.
+//
+// It returns the ID of the marker and the IDs of the stale markers it
+// deleted.
+func (w *todoWriter) acquireLock(ctx context.Context, name string, holder todoLockHolder, timeout, pollInterval, ttl time.Duration) (int64, []int64, error) {
+	marker, err := lockMarker(name, holder)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	// Create the marker with AddOne, which the server applies atomically,
+	// going through the idempotent create so a lost response leaves no
+	// second marker behind
+	id, err := w.createStored(ctx, marker, false)
+	if err != nil {
+		if id != 0 {
+			err = w.abandonLock(id, err)
+		}
+		return 0, nil, err
+	}
+
+	deadline := time.Now().Add(timeout)
+	broken := []int64{}
+	for attempt := 1; ; attempt++ {
+		markers, err := w.findLockMarkers(ctx, name)
+		if err != nil {
+			return 0, nil, w.abandonLock(id, err)
+		}
+
+		// Another holder may have broken our marker as stale while we
+		// waited, and then the lock is no longer ours to take
+		if !hasLockMarker(markers, id) {
+			return 0, nil, fmt.Errorf("the marker todo ID %d of lock %s was deleted while waiting for the lock, "+
+				"most likely broken as stale by another holder; consider a longer ttl", id, name)
+		}
+
+		var blocker *todoLockMarker
+		for i, other := range markers {
+			if other.ID == id {
+				continue
+			}
+			if ttl > 0 && time.Since(other.Holder.AcquiredAt) > ttl {
+				tflog.Warn(ctx, "Breaking stale lock", map[string]any{
+					"name":        name,
+					"ID":          other.ID,
+					"holder":      other.Holder.Holder,
+					"acquired_at": other.Holder.AcquiredAt.Format(time.RFC3339)})
+				if err := w.delete(other.ID); err != nil {
+					return 0, nil, w.abandonLock(id, fmt.Errorf("could not break stale lock marker todo ID %d: %w", other.ID, err))
+				}
+				broken = append(broken, other.ID)
+				continue
+			}
+			if other.ID < id && blocker == nil {
+				blocker = &markers[i]
+			}
+		}
+		if blocker == nil {
+			tflog.Info(ctx, "Acquired lock", map[string]any{"name": name, "ID": id, "attempt": attempt})
+			return id, broken, nil
+		}
+
+		if time.Now().Add(pollInterval).After(deadline) {
+			return 0, nil, w.abandonLock(id, fmt.Errorf("timed out after %s waiting for lock %s, held by %q since %s (todo ID %d)",
+				timeout, name, blocker.Holder.Holder, blocker.Holder.AcquiredAt.Format(time.RFC3339), blocker.ID))
+		}
+		tflog.Debug(ctx, "Waiting for lock", map[string]any{
+			"name":    name,
+			"holder":  blocker.Holder.Holder,
+			"attempt": attempt})
+		select {
+		case <-ctx.Done():
+			return 0, nil, w.abandonLock(id, ctx.Err())
+		case <-time.After(pollInterval):
+		}
+	}
+}
+
+// hasLockMarker reports whether markers include the marker todo with ID id.
+func hasLockMarker(markers []todoLockMarker, id int64) bool {
+	for _, marker := range markers {
+		if marker.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// abandonLock deletes the marker todo of a lock that was not acquired and
+// returns err, adding any error deleting the marker to it.
+func (w *todoWriter) abandonLock(id int64, err error) error {
+	if deleteErr := w.delete(id); deleteErr != nil {
+		return fmt.Errorf("%w; the lock marker todo ID %d could not be deleted either: %s", err, id, deleteErr)
+	}
+	return err
+}
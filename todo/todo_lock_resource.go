@@ -0,0 +1,325 @@
+package todo
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	// defaultLockTimeout is how long todo_lock waits for a lock by default.
+	defaultLockTimeout = 5 * time.Minute
+	// defaultLockPollInterval is how often todo_lock checks a lock it waits
+	// for by default.
+	defaultLockPollInterval = 5 * time.Second
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                   = &todoLockResource{}
+	_ resource.ResourceWithConfigure      = &todoLockResource{}
+	_ resource.ResourceWithValidateConfig = &todoLockResource{}
+)
+
+// NewTodoLockResource is a helper function to simplify the provider implementation.
+func NewTodoLockResource() resource.Resource {
+	return &todoLockResource{}
+}
+
+// todoLockResource is the resource implementation.
+type todoLockResource struct {
+	writer *todoWriter
+}
+
+// todoLockResourceModel maps the resource schema data.
+type todoLockResourceModel struct {
+	ID           types.String `tfsdk:"id"`
+	Name         types.String `tfsdk:"name"`
+	Holder       types.String `tfsdk:"holder"`
+	Timeout      types.String `tfsdk:"timeout"`
+	PollInterval types.String `tfsdk:"poll_interval"`
+	TTL          types.String `tfsdk:"ttl"`
+	TodoID       types.Int64  `tfsdk:"todo_id"`
+	AcquiredAt   types.String `tfsdk:"acquired_at"`
+	BrokenIDs    types.List   `tfsdk:"broken_ids"`
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *todoLockResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	r.writer = newTodoWriter(req.ProviderData.(*todoProviderData))
+}
+
+// Metadata returns the resource type name.
+func (r *todoLockResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_lock"
+}
+
+// Schema defines the schema for the resource.
+func (r *todoLockResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Hold a named lock shared through the Todo server, for example to keep pipelines from running a manual " +
+			"operation at the same time. The lock is acquired by creating a marker todo and is held while that marker has " +
+			"the lowest ID of the lock's markers. It is released when the resource is destroyed.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The lock name and marker todo ID.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description: "The name of the lock, made of letters, digits, `_`, `.` and `-`.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"holder": schema.StringAttribute{
+				Description: "Who holds the lock, such as a pipeline URL, shown to anyone waiting for it (default: the host name).",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"timeout": schema.StringAttribute{
+				Description: "How long to wait for the lock before failing (default: 5m).",
+				Optional:    true,
+			},
+			"poll_interval": schema.StringAttribute{
+				Description: "How often to check the lock while waiting for it (default: 5s).",
+				Optional:    true,
+			},
+			"ttl": schema.StringAttribute{
+				Description: "Break locks acquired longer than this ago, such as locks left behind by a pipeline that was killed. " +
+					"It should be longer than any holder keeps the lock, and than the timeout of anyone waiting. Locks are never " +
+					"broken if unset.",
+				Optional: true,
+			},
+			"todo_id": schema.Int64Attribute{
+				Description: "The unique identifier of the marker todo.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"acquired_at": schema.StringAttribute{
+				Description: "When the lock was acquired, in RFC 3339 format.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"broken_ids": schema.ListAttribute{
+				Description: "The marker todo IDs of the stale locks that were broken to acquire the lock.",
+				Computed:    true,
+				ElementType: types.Int64Type,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+// ValidateConfig checks the lock name and durations.
+func (r *todoLockResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config todoLockResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !config.Name.IsUnknown() && !lockNamePattern.MatchString(config.Name.ValueString()) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("name"),
+			"Invalid Lock Name",
+			"The name must be made of letters, digits, '_', '.' and '-', got: '"+config.Name.ValueString()+"'.",
+		)
+	}
+	if !config.Timeout.IsUnknown() {
+		_, diags := parseLockDuration(config.Timeout, "timeout", defaultLockTimeout)
+		resp.Diagnostics.Append(diags...)
+	}
+	if !config.PollInterval.IsUnknown() {
+		pollInterval, diags := parseLockDuration(config.PollInterval, "poll_interval", defaultLockPollInterval)
+		resp.Diagnostics.Append(diags...)
+		if !diags.HasError() && pollInterval == 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("poll_interval"),
+				"Invalid Duration",
+				"The poll_interval must be longer than 0s.",
+			)
+		}
+	}
+	if !config.TTL.IsUnknown() {
+		_, diags := parseLockDuration(config.TTL, "ttl", 0)
+		resp.Diagnostics.Append(diags...)
+	}
+}
+
+// Create acquires the lock, waiting for it up to the timeout.
+func (r *todoLockResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	tflog.Debug(ctx, "Preparing to create todo lock resource")
+	// Retrieve values from plan
+	var plan todoLockResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	timeout, diags := parseLockDuration(plan.Timeout, "timeout", defaultLockTimeout)
+	resp.Diagnostics.Append(diags...)
+	pollInterval, diags := parseLockDuration(plan.PollInterval, "poll_interval", defaultLockPollInterval)
+	resp.Diagnostics.Append(diags...)
+	ttl, diags := parseLockDuration(plan.TTL, "ttl", 0)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.Holder.IsUnknown() || plan.Holder.IsNull() {
+		hostname, err := os.Hostname()
+		if err != nil {
+			hostname = "terraform"
+		}
+		plan.Holder = types.StringValue(hostname)
+	}
+	holder := todoLockHolder{
+		Holder:     plan.Holder.ValueString(),
+		AcquiredAt: time.Now().UTC().Truncate(time.Second),
+	}
+
+	id, broken, err := r.writer.acquireLock(ctx, plan.Name.ValueString(), holder, timeout, pollInterval, ttl)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Acquiring Lock",
+			"Could not acquire lock "+plan.Name.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	plan.TodoID = types.Int64Value(id)
+	plan.ID = types.StringValue(plan.Name.ValueString() + ":" + plan.TodoID.String())
+	plan.AcquiredAt = types.StringValue(holder.AcquiredAt.Format(time.RFC3339))
+	plan.BrokenIDs, diags = types.ListValueFrom(ctx, types.Int64Type, broken)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	tflog.Debug(ctx, "Created todo lock resource", map[string]any{"success": !resp.Diagnostics.HasError()})
+}
+
+// Read checks that the marker todo still exists. A lock whose marker was
+// deleted, for example because another pipeline broke it as stale, is
+// removed from state so the next apply acquires it again.
+func (r *todoLockResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	tflog.Debug(ctx, "Preparing to read todo lock resource")
+	// Get current state
+	var state todoLockResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	item, err := r.writer.readStored(state.TodoID.ValueInt64())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Todo",
+			"Could not read lock marker todo ID "+state.TodoID.String()+": "+err.Error(),
+		)
+		return
+	}
+	name, holder, ok := "", todoLockHolder{}, false
+	if item != nil {
+		name, holder, ok = parseLockMarker(*item.Description)
+	}
+	if !ok || name != state.Name.ValueString() || holder.AcquiredAt.Format(time.RFC3339) != state.AcquiredAt.ValueString() {
+		tflog.Warn(ctx, "Lock marker todo no longer exists, the lock was released or broken", map[string]any{
+			"name": state.Name.ValueString(),
+			"ID":   state.TodoID.String()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	tflog.Debug(ctx, "Finished reading todo lock resource", map[string]any{"success": true})
+}
+
+// Update records new timeouts, which only apply to the next acquisition.
+func (r *todoLockResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan todoLockResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete releases the lock by deleting its marker todo.
+func (r *todoLockResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	tflog.Debug(ctx, "Preparing to delete todo lock resource")
+	// Retrieve values from state
+	var state todoLockResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.writer.delete(state.TodoID.ValueInt64()); err != nil {
+		resp.Diagnostics.AddError(
+			"Error Deleting Todo",
+			"Could not release lock "+state.Name.ValueString()+", unexpected error deleting todo ID "+
+				state.TodoID.String()+": "+err.Error(),
+		)
+		return
+	}
+	tflog.Debug(ctx, "Deleted todo lock resource", map[string]any{"success": true})
+}
+
+// parseLockDuration parses a duration attribute of todo_lock, which
+// defaults to defaultValue.
+func parseLockDuration(value types.String, attribute string, defaultValue time.Duration) (time.Duration, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if value.IsNull() {
+		return defaultValue, diags
+	}
+
+	duration, err := time.ParseDuration(value.ValueString())
+	if err != nil || duration < 0 {
+		diags.AddAttributeError(
+			path.Root(attribute),
+			"Invalid Duration",
+			"The "+attribute+" must be a duration such as '5m' or '30s', got: '"+value.ValueString()+"'.",
+		)
+	}
+	return duration, diags
+}
@@ -0,0 +1,47 @@
+package todo
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccTodoLockResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: providerConfig + `
+resource "todo_lock" "test" {
+	name    = "acc-lock"
+	holder  = "acceptance test"
+	timeout = "30s"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("todo_lock.test", "holder", "acceptance test"),
+					resource.TestCheckResourceAttr("todo_lock.test", "broken_ids.#", "0"),
+					resource.TestCheckResourceAttrSet("todo_lock.test", "todo_id"),
+					resource.TestCheckResourceAttrSet("todo_lock.test", "acquired_at"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestParseLockDuration(t *testing.T) {
+	if duration, diags := parseLockDuration(types.StringNull(), "timeout", defaultLockTimeout); duration != defaultLockTimeout || diags.HasError() {
+		t.Errorf("expected the default, got %s (%v)", duration, diags)
+	}
+	if duration, diags := parseLockDuration(types.StringValue("90s"), "timeout", defaultLockTimeout); duration.Seconds() != 90 || diags.HasError() {
+		t.Errorf("expected 90s, got %s (%v)", duration, diags)
+	}
+	for _, value := range []string{"soon", "-1m"} {
+		if _, diags := parseLockDuration(types.StringValue(value), "ttl", 0); !diags.HasError() {
+			t.Errorf("expected %q to be invalid", value)
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package todo
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLockMarker(t *testing.T) {
+	holder := todoLockHolder{Holder: "pipeline #42", AcquiredAt: time.Date(2024, 6, 30, 17, 0, 0, 0, time.UTC)}
+	marker, err := lockMarker("deploy", holder)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, description := range []string{marker, withPendingMarker(marker, "1719766800-0f1e2d3c")} {
+		name, parsed, ok := parseLockMarker(description)
+		if !ok || name != "deploy" || parsed.Holder != holder.Holder || !parsed.AcquiredAt.Equal(holder.AcquiredAt) {
+			t.Errorf("unexpected lock %q %+v (%t) from %q", name, parsed, ok, description)
+		}
+	}
+
+	for _, description := range []string{"Go Shopping", "[todo-lock:deploy] not json", "[todo-lock:a b] {}"} {
+		if _, _, ok := parseLockMarker(description); ok {
+			t.Errorf("expected %q not to be a lock marker", description)
+		}
+	}
+}
+
+func TestTodoWriterFindLockMarkers(t *testing.T) {
+	deploy, _ := lockMarker("deploy", todoLockHolder{Holder: "first"})
+	later, _ := lockMarker("deploy", todoLockHolder{Holder: "second"})
+	other, _ := lockMarker("migrate", todoLockHolder{Holder: "third"})
+
+	w := &todoWriter{client: newTestTodoClient(t, func(rw http.ResponseWriter, r *http.Request) {
+		items := []map[string]any{
+			{"id": 7, "description": later, "completed": false},
+			{"id": 3, "description": deploy, "completed": false},
+			{"id": 5, "description": other, "completed": false},
+			{"id": 6, "description": "Go Shopping", "completed": false},
+		}
+		if r.URL.Query().Get("since") != "0" {
+			items = nil
+		}
+		rw.Header().Set("Content-Type", "application/spkane.todo-list.v1+json")
+		_ = json.NewEncoder(rw).Encode(items)
+	})}
+
+	markers, err := w.findLockMarkers(context.Background(), "deploy")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(markers) != 2 || markers[0].ID != 3 || markers[0].Holder.Holder != "first" || markers[1].ID != 7 {
+		t.Errorf("unexpected markers %+v", markers)
+	}
+}
+
+func TestTodoWriterAcquireLockFailsWhenMarkerIsBroken(t *testing.T) {
+	blocker, _ := lockMarker("deploy", todoLockHolder{Holder: "first", AcquiredAt: time.Now()})
+	server, c := newFakeTodoServer(t, map[int64]map[string]any{
+		1: {"id": 1, "description": blocker, "completed": false},
+	})
+	w := &todoWriter{client: c}
+
+	// While we wait, another holder breaks our marker as stale and then
+	// releases the lock it held
+	lists := 0
+	server.onList = func(todos map[int64]map[string]any) {
+		lists++
+		if lists == 2 {
+			delete(todos, 1)
+			delete(todos, 2)
+		}
+	}
+
+	holder := todoLockHolder{Holder: "second", AcquiredAt: time.Now()}
+	_, _, err := w.acquireLock(context.Background(), "deploy", holder, 5*time.Second, 10*time.Millisecond, 0)
+	if err == nil || !strings.Contains(err.Error(), "deleted while waiting") {
+		t.Fatalf("expected acquiring to fail once the marker was broken, got %v", err)
+	}
+	if server.get(2) != nil {
+		t.Errorf("expected no marker to be left, got %v", server.get(2))
+	}
+}
@@ -0,0 +1,68 @@
+package todo
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// markdownTaskPattern matches a GitHub-flavored Markdown task list item such
+// as "- [ ] Go Shopping" or "1. [x] Walk the dog", at any indentation.
+var markdownTaskPattern = regexp.MustCompile(`^\s*(?:[-*+]|\d+[.)])\s+\[([ xX])\]\s+(\S.*?)\s*$`)
+
+// markdownTask is a task list item parsed from Markdown.
+type markdownTask struct {
+	// Line is the 1-based line number of the item.
+	Line int
+	// Key identifies the item across edits of the Markdown.
+	Key       string
+	Text      string
+	Completed bool
+}
+
+// parseMarkdownTasks returns the task list items in Markdown content, in
+// order. Items inside fenced code blocks are ignored. Two items with the same
+// key are an error, since they could not be told apart.
+func parseMarkdownTasks(content string) ([]markdownTask, error) {
+	var tasks []markdownTask
+	lines := make(map[string]int)
+	fence := ""
+
+	for i, line := range strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n") {
+		trimmed := strings.TrimSpace(line)
+		if fence != "" {
+			if strings.HasPrefix(trimmed, fence) {
+				fence = ""
+			}
+			continue
+		}
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			fence = trimmed[:3]
+			continue
+		}
+
+		match := markdownTaskPattern.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+
+		task := markdownTask{
+			Line:      i + 1,
+			Key:       markdownTaskKey(match[2]),
+			Text:      match[2],
+			Completed: match[1] != " ",
+		}
+		if first, ok := lines[task.Key]; ok {
+			return nil, fmt.Errorf("lines %d and %d are the same task %q; task text must be unique", first, task.Line, task.Key)
+		}
+		lines[task.Key] = task.Line
+		tasks = append(tasks, task)
+	}
+	return tasks, nil
+}
+
+// markdownTaskKey normalizes task text into the key that identifies it, so
+// changes to letter case or spacing update a todo instead of replacing it.
+func markdownTaskKey(text string) string {
+	return strings.Join(strings.Fields(normalizeDescription(text, descriptionNormalizationCaseInsensitive)), " ")
+}
@@ -0,0 +1,427 @@
+package todo
+
+import (
+	"context"
+
+	"github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource               = &todoMarkdownSyncResource{}
+	_ resource.ResourceWithConfigure  = &todoMarkdownSyncResource{}
+	_ resource.ResourceWithModifyPlan = &todoMarkdownSyncResource{}
+)
+
+// markdownSyncItemType is the object type of an entry in the items attribute.
+var markdownSyncItemType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"line":        types.Int64Type,
+		"key":         types.StringType,
+		"description": types.StringType,
+		"completed":   types.BoolType,
+		"id":          types.Int64Type,
+	},
+}
+
+// NewTodoMarkdownSyncResource is a helper function to simplify the provider implementation.
+func NewTodoMarkdownSyncResource() resource.Resource {
+	return &todoMarkdownSyncResource{}
+}
+
+// todoMarkdownSyncResource is the resource implementation.
+type todoMarkdownSyncResource struct {
+	writer *todoWriter
+}
+
+// todoMarkdownSyncResourceModel maps the resource schema data.
+type todoMarkdownSyncResourceModel struct {
+	ID      types.String `tfsdk:"id"`
+	Content types.String `tfsdk:"content"`
+	Items   types.List   `tfsdk:"items"`
+	IDs     types.Map    `tfsdk:"ids"`
+}
+
+// markdownSyncItemModel maps an entry in the items attribute.
+type markdownSyncItemModel struct {
+	Line        types.Int64  `tfsdk:"line"`
+	Key         types.String `tfsdk:"key"`
+	Description types.String `tfsdk:"description"`
+	Completed   types.Bool   `tfsdk:"completed"`
+	ID          types.Int64  `tfsdk:"id"`
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *todoMarkdownSyncResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	r.writer = newTodoWriter(req.ProviderData.(*todoProviderData))
+}
+
+// Metadata returns the resource type name.
+func (r *todoMarkdownSyncResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_markdown_sync"
+}
+
+// Schema defines the schema for the resource.
+func (r *todoMarkdownSyncResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Keep a todo for every item of a Markdown task list, such as `- [ ] Tag the release` or `- [x] Update the CHANGELOG`. " +
+			"Items are matched to todos by their text, ignoring letter case and spacing, so checking an item off or rewording " +
+			"its capitalization updates its todo, while adding or removing an item creates or deletes one.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "A unique identifier for the synced checklist.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"content": schema.StringAttribute{
+				Description: "The Markdown to sync, for example from `file()`. Task list items inside fenced code blocks are ignored, " +
+					"and two items with the same text are an error.",
+				Required: true,
+			},
+			"items": schema.ListNestedAttribute{
+				Description: "The task list items, in the order they appear in the Markdown.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"line": schema.Int64Attribute{
+							Description: "The line number of the item.",
+							Computed:    true,
+						},
+						"key": schema.StringAttribute{
+							Description: "The normalized item text that matches the item to its todo.",
+							Computed:    true,
+						},
+						"description": schema.StringAttribute{
+							Description: "The item text, used as the todo description.",
+							Computed:    true,
+						},
+						"completed": schema.BoolAttribute{
+							Description: "Whether the item is checked.",
+							Computed:    true,
+						},
+						"id": schema.Int64Attribute{
+							Description: "The unique identifier of the item's todo.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"ids": schema.MapAttribute{
+				Description: "The unique identifiers of the item todos, keyed by item key.",
+				Computed:    true,
+				ElementType: types.Int64Type,
+			},
+		},
+	}
+}
+
+// ModifyPlan plans the items parsed from the Markdown, keeping the IDs of
+// items that already have a todo.
+func (r *todoMarkdownSyncResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to plan when the resource is being destroyed
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan todoMarkdownSyncResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() || plan.Content.IsUnknown() {
+		return
+	}
+
+	tasks, err := parseMarkdownTasks(plan.Content.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("content"),
+			"Invalid Markdown Task List",
+			err.Error(),
+		)
+		return
+	}
+
+	existing := map[string]int64{}
+	if !req.State.Raw.IsNull() {
+		var state todoMarkdownSyncResourceModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		items, diags := markdownSyncItems(ctx, state.Items)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		for _, item := range items {
+			existing[item.Key.ValueString()] = item.ID.ValueInt64()
+		}
+	}
+
+	items := make([]markdownSyncItemModel, 0, len(tasks))
+	for _, task := range tasks {
+		id := types.Int64Unknown()
+		if existingID, ok := existing[task.Key]; ok {
+			id = types.Int64Value(existingID)
+		}
+		items = append(items, newMarkdownSyncItem(task, id))
+	}
+
+	resp.Diagnostics.Append(plan.setItems(ctx, items)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, plan)...)
+}
+
+// Create creates a todo for every task list item.
+func (r *todoMarkdownSyncResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	tflog.Debug(ctx, "Preparing to create todo markdown sync resource")
+	// Retrieve values from plan
+	var plan todoMarkdownSyncResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id, err := uuid.GenerateUUID()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Creating Markdown Sync",
+			"Could not generate an ID, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	plan.ID = types.StringValue(id)
+
+	resp.Diagnostics.Append(r.sync(ctx, &plan, nil)...)
+
+	// Save the todos that were created even if others failed
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+	tflog.Debug(ctx, "Created todo markdown sync resource", map[string]any{"success": !resp.Diagnostics.HasError()})
+}
+
+// Read refreshes the item todos, dropping the ones deleted from the server so
+// they are planned to be created again.
+func (r *todoMarkdownSyncResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	tflog.Debug(ctx, "Preparing to read todo markdown sync resource")
+	// Get current state
+	var state todoMarkdownSyncResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	items, diags := markdownSyncItems(ctx, state.Items)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	entries, err := r.writer.refresh(ctx, markdownSyncEntries(items))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Todos",
+			"Could not read markdown sync todos: "+err.Error(),
+		)
+		return
+	}
+	refreshed := markdownSyncItemsFromEntries(entries, items)
+
+	resp.Diagnostics.Append(state.setItems(ctx, refreshed)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	tflog.Debug(ctx, "Finished reading todo markdown sync resource", map[string]any{"success": true})
+}
+
+// Update creates, updates and deletes todos to match the task list.
+func (r *todoMarkdownSyncResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	tflog.Debug(ctx, "Preparing to update todo markdown sync resource")
+	// Retrieve values from plan
+	var plan todoMarkdownSyncResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Retrieve the todos that exist from state
+	var state todoMarkdownSyncResourceModel
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	existing, diags := markdownSyncItems(ctx, state.Items)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.sync(ctx, &plan, existing)...)
+
+	// Save the changes that were made even if others failed
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+	tflog.Debug(ctx, "Updated todo markdown sync resource", map[string]any{"success": !resp.Diagnostics.HasError()})
+}
+
+// Delete deletes every item todo.
+func (r *todoMarkdownSyncResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	tflog.Debug(ctx, "Preparing to delete todo markdown sync resource")
+	// Retrieve values from state
+	var state todoMarkdownSyncResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	items, diags := markdownSyncItems(ctx, state.Items)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for _, item := range items {
+		if err := r.writer.delete(item.ID.ValueInt64()); err != nil {
+			resp.Diagnostics.AddError(
+				"Error Deleting Todo",
+				"Could not delete todo ID "+item.ID.String()+", unexpected error: "+err.Error(),
+			)
+		}
+	}
+	tflog.Debug(ctx, "Deleted todo markdown sync resource", map[string]any{"success": !resp.Diagnostics.HasError()})
+}
+
+// sync makes the todos on the server match the task list in plan, given the
+// items that already have todos, leaving plan with the items that have todos
+// afterwards.
+func (r *todoMarkdownSyncResource) sync(ctx context.Context, plan *todoMarkdownSyncResourceModel, existing []markdownSyncItemModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	tasks, err := parseMarkdownTasks(plan.Content.ValueString())
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root("content"),
+			"Invalid Markdown Task List",
+			err.Error(),
+		)
+		return diags
+	}
+
+	targets := make([]todoSyncTarget, 0, len(tasks))
+	for _, task := range tasks {
+		targets = append(targets, todoSyncTarget{
+			Key:         task.Key,
+			Description: task.Text,
+			Completed:   task.Completed,
+		})
+	}
+
+	entries, syncDiags := r.writer.sync(ctx, targets, markdownSyncEntries(existing), 1, false)
+	diags.Append(syncDiags...)
+
+	// Synced items are on the lines of the new content
+	lines := make([]markdownSyncItemModel, 0, len(tasks))
+	for _, task := range tasks {
+		lines = append(lines, newMarkdownSyncItem(task, types.Int64Null()))
+	}
+	items := markdownSyncItemsFromEntries(entries, append(lines, existing...))
+
+	diags.Append(plan.setItems(ctx, items)...)
+	return diags
+}
+
+// markdownSyncEntries converts items to sync entries.
+func markdownSyncEntries(items []markdownSyncItemModel) []todoSyncEntry {
+	entries := make([]todoSyncEntry, 0, len(items))
+	for _, item := range items {
+		entries = append(entries, todoSyncEntry{
+			Key:         item.Key.ValueString(),
+			ID:          item.ID.ValueInt64(),
+			Description: item.Description.ValueString(),
+			Completed:   item.Completed.ValueBool(),
+		})
+	}
+	return entries
+}
+
+// markdownSyncItemsFromEntries converts sync entries to items, taking the
+// line of each from the first of items with the same key.
+func markdownSyncItemsFromEntries(entries []todoSyncEntry, items []markdownSyncItemModel) []markdownSyncItemModel {
+	lines := make(map[string]types.Int64, len(items))
+	for _, item := range items {
+		if _, ok := lines[item.Key.ValueString()]; !ok {
+			lines[item.Key.ValueString()] = item.Line
+		}
+	}
+
+	converted := make([]markdownSyncItemModel, 0, len(entries))
+	for _, entry := range entries {
+		converted = append(converted, markdownSyncItemModel{
+			Line:        lines[entry.Key],
+			Key:         types.StringValue(entry.Key),
+			Description: types.StringValue(entry.Description),
+			Completed:   types.BoolValue(entry.Completed),
+			ID:          types.Int64Value(entry.ID),
+		})
+	}
+	return converted
+}
+
+// newMarkdownSyncItem returns the items entry for a task.
+func newMarkdownSyncItem(task markdownTask, id types.Int64) markdownSyncItemModel {
+	return markdownSyncItemModel{
+		Line:        types.Int64Value(int64(task.Line)),
+		Key:         types.StringValue(task.Key),
+		Description: types.StringValue(task.Text),
+		Completed:   types.BoolValue(task.Completed),
+		ID:          id,
+	}
+}
+
+// markdownSyncItems converts the items attribute to its entries. Null and
+// unknown lists have no entries.
+func markdownSyncItems(ctx context.Context, list types.List) ([]markdownSyncItemModel, diag.Diagnostics) {
+	var items []markdownSyncItemModel
+	if list.IsNull() || list.IsUnknown() {
+		return items, nil
+	}
+	diags := list.ElementsAs(ctx, &items, false)
+	return items, diags
+}
+
+// setItems sets the items and ids attributes from the item entries.
+func (m *todoMarkdownSyncResourceModel) setItems(ctx context.Context, items []markdownSyncItemModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	ids := make(map[string]attr.Value, len(items))
+	for _, item := range items {
+		ids[item.Key.ValueString()] = item.ID
+	}
+
+	list, listDiags := types.ListValueFrom(ctx, markdownSyncItemType, items)
+	diags.Append(listDiags...)
+	idMap, mapDiags := types.MapValue(types.Int64Type, ids)
+	diags.Append(mapDiags...)
+	m.Items = list
+	m.IDs = idMap
+	return diags
+}
@@ -0,0 +1,55 @@
+package todo
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccTodoMarkdownSyncResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: providerConfig + `
+resource "todo_markdown_sync" "test" {
+	content = <<-EOT
+		# Release
+		- [ ] Tag the release
+		- [x] Update the CHANGELOG
+	EOT
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("todo_markdown_sync.test", "items.#", "2"),
+					resource.TestCheckResourceAttr("todo_markdown_sync.test", "items.0.line", "2"),
+					resource.TestCheckResourceAttr("todo_markdown_sync.test", "items.0.description", "Tag the release"),
+					resource.TestCheckResourceAttr("todo_markdown_sync.test", "items.0.completed", "false"),
+					resource.TestCheckResourceAttr("todo_markdown_sync.test", "items.1.completed", "true"),
+					resource.TestCheckResourceAttrSet("todo_markdown_sync.test", "ids.tag the release"),
+					resource.TestCheckResourceAttrSet("todo_markdown_sync.test", "id"),
+				),
+			},
+			// Update and Read testing
+			{
+				Config: providerConfig + `
+resource "todo_markdown_sync" "test" {
+	content = <<-EOT
+		# Release
+		- [x] Tag the release
+		- [ ] Announce the release
+	EOT
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("todo_markdown_sync.test", "items.#", "2"),
+					resource.TestCheckResourceAttr("todo_markdown_sync.test", "items.0.completed", "true"),
+					resource.TestCheckResourceAttr("todo_markdown_sync.test", "items.1.description", "Announce the release"),
+					resource.TestCheckNoResourceAttr("todo_markdown_sync.test", "ids.update the changelog"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
@@ -0,0 +1,43 @@
+package todo
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseMarkdownTasks(t *testing.T) {
+	content := "# Release\n" +
+		"\n" +
+		"- [ ] Tag the release\n" +
+		"- [x] Update the  CHANGELOG  \n" +
+		"  * [X] Nested item\n" +
+		"1. [ ] Numbered item\n" +
+		"- [] Not a task\n" +
+		"- plain bullet\n" +
+		"```\n" +
+		"- [ ] Inside a code block\n" +
+		"```\n" +
+		"+ [ ] After the code block\r\n"
+
+	tasks, err := parseMarkdownTasks(content)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []markdownTask{
+		{Line: 3, Key: "tag the release", Text: "Tag the release", Completed: false},
+		{Line: 4, Key: "update the changelog", Text: "Update the  CHANGELOG", Completed: true},
+		{Line: 5, Key: "nested item", Text: "Nested item", Completed: true},
+		{Line: 6, Key: "numbered item", Text: "Numbered item", Completed: false},
+		{Line: 12, Key: "after the code block", Text: "After the code block", Completed: false},
+	}
+	if !reflect.DeepEqual(tasks, expected) {
+		t.Errorf("expected %+v, got %+v", expected, tasks)
+	}
+}
+
+func TestParseMarkdownTasksDuplicate(t *testing.T) {
+	if _, err := parseMarkdownTasks("- [ ] Tag the release\n- [x] tag the  release\n"); err == nil {
+		t.Errorf("expected an error for duplicate tasks")
+	}
+}
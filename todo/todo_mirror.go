@@ -0,0 +1,175 @@
+package todo
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	mirrorActionCreate = "create"
+	mirrorActionUpdate = "update"
+	mirrorActionDelete = "delete"
+)
+
+// todoMirrorChange is a change to the target server that makes it match the
+// source server.
+type todoMirrorChange struct {
+	action      string
+	sourceID    int64
+	targetID    int64
+	description string
+	completed   bool
+}
+
+// String describes the change for the changes attribute of todo_mirror.
+func (c todoMirrorChange) String() string {
+	switch c.action {
+	case mirrorActionCreate:
+		if c.targetID != 0 {
+			return fmt.Sprintf("create %d -> %d", c.sourceID, c.targetID)
+		}
+		return fmt.Sprintf("create %d", c.sourceID)
+	case mirrorActionUpdate:
+		return fmt.Sprintf("update %d -> %d", c.sourceID, c.targetID)
+	default:
+		return fmt.Sprintf("delete %d -> %d", c.sourceID, c.targetID)
+	}
+}
+
+// todoMirrorPlan is what a mirror has to change on the target server.
+type todoMirrorPlan struct {
+	// changes deletes the mirrored todos that are no longer in scope, then
+	// creates and updates the others in source ID order.
+	changes []todoMirrorChange
+	// kept maps the source todos whose mirrored todo is still on the target
+	// server to its ID.
+	kept map[int64]int64
+}
+
+// planMirror works out the changes that make the target todos mirror the
+// source todos whose description starts with prefix, given the ID map of
+// the previous mirror. Todos are compared with their descriptions as stored.
+// Source todos that are still being created are left out, and target todos
+// that are not in the ID map are never touched.
+func planMirror(source, target []todoSnapshotItem, previous map[int64]int64, prefix string) todoMirrorPlan {
+	plan := todoMirrorPlan{kept: make(map[int64]int64, len(previous))}
+
+	targets := make(map[int64]todoSnapshotItem, len(target))
+	for _, item := range target {
+		targets[item.ID] = item
+	}
+	mirrored := make(map[int64]bool, len(source))
+	var inScope []todoSnapshotItem
+	for _, item := range source {
+		if _, token, _ := parsePendingMarker(item.Description); token != "" {
+			continue
+		}
+		if !strings.HasPrefix(item.Description, prefix) {
+			continue
+		}
+		mirrored[item.ID] = true
+		inScope = append(inScope, item)
+	}
+
+	// Delete mirrored todos whose source todo is gone or out of scope
+	sourceIDs := make([]int64, 0, len(previous))
+	for sourceID := range previous {
+		sourceIDs = append(sourceIDs, sourceID)
+	}
+	sort.Slice(sourceIDs, func(i, j int) bool { return sourceIDs[i] < sourceIDs[j] })
+	for _, sourceID := range sourceIDs {
+		targetID := previous[sourceID]
+		if _, ok := targets[targetID]; !ok {
+			continue
+		}
+		if mirrored[sourceID] {
+			plan.kept[sourceID] = targetID
+			continue
+		}
+		plan.changes = append(plan.changes, todoMirrorChange{
+			action:   mirrorActionDelete,
+			sourceID: sourceID,
+			targetID: targetID,
+		})
+	}
+
+	for _, item := range inScope {
+		targetID, ok := plan.kept[item.ID]
+		if !ok {
+			plan.changes = append(plan.changes, todoMirrorChange{
+				action:      mirrorActionCreate,
+				sourceID:    item.ID,
+				description: item.Description,
+				completed:   item.Completed,
+			})
+			continue
+		}
+		current := targets[targetID]
+		if current.Description == item.Description && current.Completed == item.Completed {
+			continue
+		}
+		plan.changes = append(plan.changes, todoMirrorChange{
+			action:      mirrorActionUpdate,
+			sourceID:    item.ID,
+			targetID:    targetID,
+			description: item.Description,
+			completed:   item.Completed,
+		})
+	}
+	return plan
+}
+
+// inSync reports whether the target server already mirrors the source and
+// the previous ID map needs no changes either.
+func (p todoMirrorPlan) inSync(previous map[int64]int64) bool {
+	return len(p.changes) == 0 && len(p.kept) == len(previous)
+}
+
+// applyMirror makes the changes of plan on the target server. It returns
+// the new ID map and the changes that were made, and collects failures
+// without stopping. Mirrored todos that could not be deleted stay in the ID
+// map, so the next apply tries again.
+func (w *todoWriter) applyMirror(ctx context.Context, plan todoMirrorPlan) (map[int64]int64, []string, []error) {
+	idMap := make(map[int64]int64, len(plan.kept))
+	for sourceID, targetID := range plan.kept {
+		idMap[sourceID] = targetID
+	}
+	made := []string{}
+	var errs []error
+
+	for _, change := range plan.changes {
+		switch change.action {
+		case mirrorActionDelete:
+			if err := w.delete(change.targetID); err != nil {
+				idMap[change.sourceID] = change.targetID
+				errs = append(errs, fmt.Errorf("could not delete mirrored todo ID %d: %w", change.targetID, err))
+				continue
+			}
+		case mirrorActionCreate:
+			targetID, err := w.createStored(ctx, change.description, change.completed)
+			if targetID != 0 {
+				idMap[change.sourceID] = targetID
+				change.targetID = targetID
+			}
+			if err != nil {
+				errs = append(errs, fmt.Errorf("could not mirror source todo ID %d: %w", change.sourceID, err))
+				continue
+			}
+		case mirrorActionUpdate:
+			if err := w.writeStored(ctx, change.targetID, change.description, change.completed); err != nil {
+				errs = append(errs, fmt.Errorf("could not update mirrored todo ID %d: %w", change.targetID, err))
+				continue
+			}
+		}
+		tflog.Debug(ctx, "Mirrored todo", map[string]any{
+			"action":    change.action,
+			"source_id": change.sourceID,
+			"target_id": change.targetID})
+		made = append(made, change.String())
+	}
+	return idMap, made, errs
+}
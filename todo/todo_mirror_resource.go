@@ -0,0 +1,363 @@
+package todo
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	// Todo API Libraries
+	"github.com/spkane/todo-for-terraform/client"
+
+	"github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource               = &todoMirrorResource{}
+	_ resource.ResourceWithConfigure  = &todoMirrorResource{}
+	_ resource.ResourceWithModifyPlan = &todoMirrorResource{}
+)
+
+// NewTodoMirrorResource is a helper function to simplify the provider implementation.
+func NewTodoMirrorResource() resource.Resource {
+	return &todoMirrorResource{}
+}
+
+// todoMirrorResource is the resource implementation.
+type todoMirrorResource struct {
+	writer *todoWriter
+	// endpoint is the URL of the target server, which the source must not be.
+	endpoint string
+}
+
+// todoMirrorResourceModel maps the resource schema data.
+type todoMirrorResourceModel struct {
+	ID                types.String `tfsdk:"id"`
+	Source            types.Object `tfsdk:"source"`
+	DescriptionPrefix types.String `tfsdk:"description_prefix"`
+	IDMap             types.Map    `tfsdk:"id_map"`
+	Changes           types.List   `tfsdk:"changes"`
+}
+
+// todoMirrorSourceModel maps the source endpoint of a mirror.
+type todoMirrorSourceModel struct {
+	Host    types.String `tfsdk:"host"`
+	Port    types.String `tfsdk:"port"`
+	Schema  types.String `tfsdk:"schema"`
+	APIPath types.String `tfsdk:"apipath"`
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *todoMirrorResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData := req.ProviderData.(*todoProviderData)
+	r.writer = newTodoWriter(providerData)
+	r.endpoint = providerData.serverProbe.endpoint
+}
+
+// Metadata returns the resource type name.
+func (r *todoMirrorResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_mirror"
+}
+
+// Schema defines the schema for the resource.
+func (r *todoMirrorResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Copy the todos of another Todo server to the provider's server, one way. Every apply creates, updates " +
+			"and deletes the mirrored todos until they match the source, which is never changed. Descriptions are copied " +
+			"exactly as stored, so encrypted descriptions stay encrypted. Todos on the provider's server that were not " +
+			"mirrored are left alone, and destroying the resource leaves the mirrored todos in place.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "A unique identifier for the mirror.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"source": schema.SingleNestedAttribute{
+				Description: "The Todo server to copy todos from. It must not be the provider's server.",
+				Required:    true,
+				Attributes: map[string]schema.Attribute{
+					"host": schema.StringAttribute{
+						Description: "The FQDN or IP address for the source Todo server (e.g. '10.0.0.12').",
+						Required:    true,
+					},
+					"port": schema.StringAttribute{
+						Description: "The port for the source Todo server (default: '8080').",
+						Optional:    true,
+					},
+					"schema": schema.StringAttribute{
+						Description: "The URL schema for the source Todo server (default: 'http').",
+						Optional:    true,
+					},
+					"apipath": schema.StringAttribute{
+						Description: "The URL path for the source Todo server API (default: '/').",
+						Optional:    true,
+					},
+				},
+			},
+			"description_prefix": schema.StringAttribute{
+				Description: "Only mirror source todos whose stored description starts with this text. Mirrored todos that " +
+					"stop matching are deleted.",
+				Optional: true,
+			},
+			"id_map": schema.MapAttribute{
+				Description: "The ID of each mirrored todo, keyed by the ID of its source todo.",
+				Computed:    true,
+				ElementType: types.Int64Type,
+			},
+			"changes": schema.ListAttribute{
+				Description: "The changes made by the latest apply that found the mirror out of date, such as 'create 3 -> 12', " +
+					"'update 3 -> 12' or 'delete 3 -> 12', each giving the source and mirrored todo IDs.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+		},
+	}
+}
+
+// ModifyPlan compares the source and target servers, planning a new ID map
+// and changes only if the mirror is out of date.
+func (r *todoMirrorResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to plan when the resource is being destroyed
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan, state todoMirrorResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	previous := map[int64]int64{}
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		var diags diag.Diagnostics
+		previous, diags = parseIDMap(ctx, state.IDMap)
+		resp.Diagnostics.Append(diags...)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Checking the servers also catches a bad source before it is created
+	mirror, known, diags := r.planMirror(ctx, &plan, previous)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !req.State.Raw.IsNull() && known && mirror.inSync(previous) {
+		plan.IDMap = state.IDMap
+		plan.Changes = state.Changes
+	} else {
+		plan.IDMap = types.MapUnknown(types.Int64Type)
+		plan.Changes = types.ListUnknown(types.StringType)
+	}
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, plan)...)
+}
+
+// Create mirrors the source todos.
+func (r *todoMirrorResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	tflog.Debug(ctx, "Preparing to create todo mirror resource")
+	// Retrieve values from plan
+	var plan todoMirrorResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id, err := uuid.GenerateUUID()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Creating Mirror",
+			"Could not generate an ID, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	plan.ID = types.StringValue(id)
+
+	resp.Diagnostics.Append(r.mirror(ctx, &plan, map[int64]int64{})...)
+	if plan.IDMap.IsUnknown() {
+		return
+	}
+
+	// Save the todos that were mirrored even if others failed
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+	tflog.Debug(ctx, "Created todo mirror resource", map[string]any{"success": !resp.Diagnostics.HasError()})
+}
+
+// Read keeps the ID map, as ModifyPlan compares the servers.
+func (r *todoMirrorResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state todoMirrorResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update brings the mirrored todos up to date with the source.
+func (r *todoMirrorResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	tflog.Debug(ctx, "Preparing to update todo mirror resource")
+	// Retrieve values from plan and state
+	var plan, state todoMirrorResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	previous, diags := parseIDMap(ctx, state.IDMap)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.mirror(ctx, &plan, previous)...)
+	if plan.IDMap.IsUnknown() {
+		// Nothing was mirrored, so keep the prior state
+		resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+		return
+	}
+
+	// Save the todos that were mirrored even if others failed
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+	tflog.Debug(ctx, "Updated todo mirror resource", map[string]any{"success": !resp.Diagnostics.HasError()})
+}
+
+// Delete removes the mirror from state, leaving the mirrored todos in place.
+func (r *todoMirrorResource) Delete(ctx context.Context, _ resource.DeleteRequest, _ *resource.DeleteResponse) {
+	tflog.Debug(ctx, "Deleted todo mirror resource", map[string]any{"success": true})
+}
+
+// mirror brings the mirrored todos of plan up to date, given the ID map of
+// the previous mirror, and sets id_map and changes. They are left unknown if
+// nothing could be mirrored.
+func (r *todoMirrorResource) mirror(ctx context.Context, plan *todoMirrorResourceModel, previous map[int64]int64) diag.Diagnostics {
+	mirror, _, diags := r.planMirror(ctx, plan, previous)
+	if diags.HasError() {
+		return diags
+	}
+	if mirror.inSync(previous) && !plan.Changes.IsUnknown() {
+		return diags
+	}
+
+	idMap, made, errs := r.writer.applyMirror(ctx, mirror)
+	tflog.Info(ctx, "Mirrored todos", map[string]any{
+		"mirrored": len(idMap),
+		"changes":  len(made),
+		"errors":   len(errs)})
+
+	keyed := make(map[string]int64, len(idMap))
+	for sourceID, targetID := range idMap {
+		keyed[strconv.FormatInt(sourceID, 10)] = targetID
+	}
+	var valueDiags diag.Diagnostics
+	plan.IDMap, valueDiags = types.MapValueFrom(ctx, types.Int64Type, keyed)
+	diags.Append(valueDiags...)
+	plan.Changes, valueDiags = types.ListValueFrom(ctx, types.StringType, made)
+	diags.Append(valueDiags...)
+
+	if len(errs) > 0 {
+		messages := make([]string, 0, len(errs))
+		for _, err := range errs {
+			messages = append(messages, err.Error())
+		}
+		diags.AddError(
+			"Error Mirroring Todos",
+			"Could not mirror some todos, the next apply tries again:\n\n"+strings.Join(messages, "\n"),
+		)
+	}
+	return diags
+}
+
+// planMirror lists the todos on the source and target servers and works out
+// the changes to the target. It returns false if the source or prefix is not
+// known yet.
+func (r *todoMirrorResource) planMirror(ctx context.Context, plan *todoMirrorResourceModel, previous map[int64]int64) (todoMirrorPlan, bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if plan.Source.IsUnknown() || plan.DescriptionPrefix.IsUnknown() || r.writer == nil {
+		return todoMirrorPlan{}, false, diags
+	}
+	var source todoMirrorSourceModel
+	diags.Append(plan.Source.As(ctx, &source, basetypes.ObjectAsOptions{})...)
+	if diags.HasError() || source.isUnknown() {
+		return todoMirrorPlan{}, false, diags
+	}
+
+	sourceClient, endpoint := source.client()
+	if endpoint == r.endpoint {
+		diags.AddAttributeError(
+			path.Root("source"),
+			"Invalid Mirror Source",
+			"The source is the Todo server the provider manages ("+endpoint+"), so the mirror would copy its own todos. "+
+				"Point the source at a different server.",
+		)
+		return todoMirrorPlan{}, false, diags
+	}
+
+	sourceTodos, err := listStoredTodos(ctx, sourceClient)
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root("source"),
+			"Unable to Read Todos",
+			"Could not read todos from the source server "+endpoint+": "+err.Error(),
+		)
+		return todoMirrorPlan{}, false, diags
+	}
+	targetTodos, err := listStoredTodos(ctx, r.writer.client)
+	if err != nil {
+		diags.AddError(
+			"Unable to Read Todos",
+			err.Error(),
+		)
+		return todoMirrorPlan{}, false, diags
+	}
+
+	mirror := planMirror(sourceTodos, targetTodos, previous, plan.DescriptionPrefix.ValueString())
+	tflog.Debug(ctx, "Planned todo mirror", map[string]any{
+		"source":  endpoint,
+		"kept":    len(mirror.kept),
+		"changes": len(mirror.changes)})
+	return mirror, true, diags
+}
+
+// isUnknown reports whether any part of the source endpoint is unknown.
+func (m *todoMirrorSourceModel) isUnknown() bool {
+	return m.Host.IsUnknown() || m.Port.IsUnknown() || m.Schema.IsUnknown() || m.APIPath.IsUnknown()
+}
+
+// client returns a Todo client for the source endpoint, using the same
+// defaults as the provider, and the endpoint URL.
+func (m *todoMirrorSourceModel) client() (*client.TodoList, string) {
+	port, schema, apipath := "8080", "http", "/"
+	if !m.Port.IsNull() {
+		port = m.Port.ValueString()
+	}
+	if !m.Schema.IsNull() {
+		schema = m.Schema.ValueString()
+	}
+	if !m.APIPath.IsNull() {
+		apipath = m.APIPath.ValueString()
+	}
+	return newTodoClient(m.Host.ValueString(), port, schema, apipath)
+}
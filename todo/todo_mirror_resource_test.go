@@ -0,0 +1,28 @@
+package todo
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccTodoMirrorResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Mirroring the provider's own server is refused
+			{
+				Config: providerConfig + `
+resource "todo_mirror" "test" {
+	source = {
+		host = "127.0.0.1"
+		port = "8080"
+	}
+}
+`,
+				ExpectError: regexp.MustCompile("Invalid Mirror Source"),
+			},
+		},
+	})
+}
@@ -0,0 +1,65 @@
+package todo
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestPlanMirror(t *testing.T) {
+	source := []todoSnapshotItem{
+		{ID: 1, Description: "mig: unchanged", Completed: false},
+		{ID: 2, Description: "mig: edited", Completed: true},
+		{ID: 3, Description: "mig: new", Completed: false},
+		{ID: 4, Description: "other", Completed: false},
+		{ID: 5, Description: withPendingMarker("mig: pending", "1719766800-0f1e2d3c"), Completed: false},
+		{ID: 7, Description: "mig: target deleted", Completed: false},
+	}
+	target := []todoSnapshotItem{
+		{ID: 10, Description: "mig: unchanged", Completed: false},
+		{ID: 11, Description: "mig: edited", Completed: false},
+		{ID: 12, Description: "mig: source deleted", Completed: false},
+		{ID: 13, Description: "local", Completed: false},
+	}
+	previous := map[int64]int64{1: 10, 2: 11, 6: 12, 7: 14}
+
+	plan := planMirror(source, target, previous, "mig: ")
+	var changes []string
+	for _, change := range plan.changes {
+		changes = append(changes, change.String())
+	}
+	expected := []string{"delete 6 -> 12", "update 2 -> 11", "create 3", "create 7"}
+	if !reflect.DeepEqual(changes, expected) {
+		t.Errorf("expected changes %v, got %v", expected, changes)
+	}
+	if !reflect.DeepEqual(plan.kept, map[int64]int64{1: 10, 2: 11}) {
+		t.Errorf("unexpected kept IDs %v", plan.kept)
+	}
+	if plan.inSync(previous) {
+		t.Error("expected the mirror to be out of date")
+	}
+
+	inSync := planMirror(source[:1], target[:1], map[int64]int64{1: 10}, "")
+	if !inSync.inSync(map[int64]int64{1: 10}) {
+		t.Errorf("expected the mirror to be in sync, got %+v", inSync)
+	}
+}
+
+func TestTodoWriterApplyMirrorKeepsFailedDeletes(t *testing.T) {
+	w := &todoWriter{client: newTestTodoClient(t, func(rw http.ResponseWriter, _ *http.Request) {
+		rw.WriteHeader(http.StatusInternalServerError)
+	})}
+
+	plan := todoMirrorPlan{
+		changes: []todoMirrorChange{{action: mirrorActionDelete, sourceID: 6, targetID: 12}},
+		kept:    map[int64]int64{1: 10},
+	}
+	idMap, made, errs := w.applyMirror(context.Background(), plan)
+	if len(errs) != 1 || len(made) != 0 {
+		t.Errorf("expected the delete to fail, got changes %v and errors %v", made, errs)
+	}
+	if !reflect.DeepEqual(idMap, map[int64]int64{1: 10, 6: 12}) {
+		t.Errorf("expected the failed delete to stay in the ID map, got %v", idMap)
+	}
+}
@@ -0,0 +1,187 @@
+package todo
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	// Todo API Libraries
+	"github.com/spkane/todo-for-terraform/client"
+	"github.com/spkane/todo-for-terraform/client/todos"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource              = &todoOrphanCleanupResource{}
+	_ resource.ResourceWithConfigure = &todoOrphanCleanupResource{}
+)
+
+// NewTodoOrphanCleanupResource is a helper function to simplify the provider implementation.
+func NewTodoOrphanCleanupResource() resource.Resource {
+	return &todoOrphanCleanupResource{}
+}
+
+// todoOrphanCleanupResource is the resource implementation.
+type todoOrphanCleanupResource struct {
+	client *client.TodoList
+}
+
+// todoOrphanCleanupResourceModel maps the resource schema data.
+type todoOrphanCleanupResourceModel struct {
+	ID         types.String `tfsdk:"id"`
+	MinAge     types.String `tfsdk:"min_age"`
+	Triggers   types.Map    `tfsdk:"triggers"`
+	DeletedIDs types.List   `tfsdk:"deleted_ids"`
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *todoOrphanCleanupResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	r.client = req.ProviderData.(*todoProviderData).client
+}
+
+// Metadata returns the resource type name.
+func (r *todoOrphanCleanupResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_orphan_cleanup"
+}
+
+// Schema defines the schema for the resource.
+func (r *todoOrphanCleanupResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Delete todos left behind on the Todo server by creates that failed after the todo was added. " +
+			"The cleanup runs when the resource is created and again whenever it is replaced.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The time the cleanup ran, in RFC 3339 format.",
+				Computed:    true,
+			},
+			"min_age": schema.StringAttribute{
+				Description: "Only delete todos whose create began at least this long ago, so creates that are still running are left alone (default: '10m').",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"triggers": schema.MapAttribute{
+				Description: "Arbitrary values that run the cleanup again when they change.",
+				Optional:    true,
+				ElementType: types.StringType,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"deleted_ids": schema.ListAttribute{
+				Description: "The unique identifiers of the orphaned todos that were deleted.",
+				Computed:    true,
+				ElementType: types.Int64Type,
+			},
+		},
+	}
+}
+
+// Create deletes the orphaned todos.
+func (r *todoOrphanCleanupResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	tflog.Debug(ctx, "Preparing to create todo orphan cleanup resource")
+	// Retrieve values from plan
+	var plan todoOrphanCleanupResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	minAge, diags := parseOrphanMinAge(plan.MinAge)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	orphans, err := findOrphanTodos(ctx, r.client, minAge)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Todos",
+			"Could not list orphaned todos, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	// Delete every orphan, recording the ones that could not be deleted
+	deletedIDs := []int64{}
+	var failures []string
+	for _, orphan := range orphans {
+		params := todos.NewDestroyOneParams()
+		params.SetID(orphan.item.ID)
+		_, err := r.client.Todos.DestroyOne(params)
+		if err != nil {
+			failures = append(failures, strconv.FormatInt(orphan.item.ID, 10)+": "+err.Error())
+			continue
+		}
+		deletedIDs = append(deletedIDs, orphan.item.ID)
+	}
+
+	plan.ID = types.StringValue(time.Now().UTC().Format(time.RFC3339))
+	plan.DeletedIDs, diags = types.ListValueFrom(ctx, types.Int64Type, deletedIDs)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if len(failures) > 0 {
+		resp.Diagnostics.AddError(
+			"Error Deleting Orphaned Todos",
+			"Could not delete some orphaned todos:\n\n"+strings.Join(failures, "\n"),
+		)
+		return
+	}
+	tflog.Debug(ctx, "Created todo orphan cleanup resource", map[string]any{"deleted": len(deletedIDs)})
+}
+
+// Read keeps the result of the cleanup, which is not stored on the server.
+func (r *todoOrphanCleanupResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state todoOrphanCleanupResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update is never called, as every configurable attribute requires replacement.
+func (r *todoOrphanCleanupResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan todoOrphanCleanupResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete removes the cleanup from state without touching the server.
+func (r *todoOrphanCleanupResource) Delete(ctx context.Context, _ resource.DeleteRequest, _ *resource.DeleteResponse) {
+	tflog.Debug(ctx, "Deleted todo orphan cleanup resource", map[string]any{"success": true})
+}
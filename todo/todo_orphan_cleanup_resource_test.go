@@ -0,0 +1,37 @@
+package todo
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccTodoOrphanCleanupResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: providerConfig + `
+resource "todo_todo" "test" {
+	description = "Go Shopping"
+	completed = false
+}
+
+resource "todo_orphan_cleanup" "test" {
+	min_age = "0s"
+
+	depends_on = [todo_todo.test]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					// Managed todos are never orphans
+					resource.TestCheckResourceAttr("todo_orphan_cleanup.test", "deleted_ids.#", "0"),
+					resource.TestCheckResourceAttrSet("todo_orphan_cleanup.test", "id"),
+					resource.TestCheckResourceAttr("todo_todo.test", "description", "Go Shopping"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
@@ -0,0 +1,185 @@
+package todo
+
+import (
+	"context"
+	"time"
+
+	// Todo API Libraries
+	"github.com/spkane/todo-for-terraform/client"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// defaultOrphanMinAge leaves creates that may still be running alone.
+const defaultOrphanMinAge = 10 * time.Minute
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &todoOrphansDataSource{}
+	_ datasource.DataSourceWithConfigure = &todoOrphansDataSource{}
+)
+
+// NewTodoOrphansDataSource is a helper function to simplify the provider implementation.
+func NewTodoOrphansDataSource() datasource.DataSource {
+	return &todoOrphansDataSource{}
+}
+
+// todoOrphansDataSource is the data source implementation.
+type todoOrphansDataSource struct {
+	client  *client.TodoList
+	keyring *todoKeyring
+}
+
+// todoOrphansDataSourceModel maps the data source schema data.
+type todoOrphansDataSourceModel struct {
+	MinAge  types.String      `tfsdk:"min_age"`
+	Orphans []todoOrphanModel `tfsdk:"orphans"`
+	IDs     []types.Int64     `tfsdk:"ids"`
+}
+
+// todoOrphanModel maps a single orphaned todo.
+type todoOrphanModel struct {
+	ID               types.Int64  `tfsdk:"id"`
+	Description      types.String `tfsdk:"description"`
+	Completed        types.Bool   `tfsdk:"completed"`
+	IdempotencyToken types.String `tfsdk:"idempotency_token"`
+	CreatedAt        types.String `tfsdk:"created_at"`
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *todoOrphansDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData := req.ProviderData.(*todoProviderData)
+	d.client = providerData.client
+	d.keyring = providerData.keyring
+}
+
+// Metadata returns the data source type name.
+func (d *todoOrphansDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_orphans"
+}
+
+// Schema defines the schema for the data source.
+func (d *todoOrphansDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "List todos left behind on the Todo server by creates that failed after the todo was added. " +
+			"Creating the same todo again adopts its orphan instead of adding a copy, once the failed create began more than a minute ago.",
+		Attributes: map[string]schema.Attribute{
+			"min_age": schema.StringAttribute{
+				Description: "Only list todos whose create began at least this long ago, so creates that are still running are left alone (default: '10m').",
+				Optional:    true,
+			},
+			"orphans": schema.ListNestedAttribute{
+				Description: "The orphaned todos.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.Int64Attribute{
+							Description: "The unique identifier for the todo.",
+							Computed:    true,
+						},
+						"description": schema.StringAttribute{
+							Description: "The description for the todo.",
+							Computed:    true,
+						},
+						"completed": schema.BoolAttribute{
+							Description: "The completed status for the todo.",
+							Computed:    true,
+						},
+						"idempotency_token": schema.StringAttribute{
+							Description: "The idempotency token of the create that left the todo behind.",
+							Computed:    true,
+						},
+						"created_at": schema.StringAttribute{
+							Description: "When the create that left the todo behind began, in RFC 3339 format.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"ids": schema.ListAttribute{
+				Description: "The unique identifiers of the orphaned todos.",
+				Computed:    true,
+				ElementType: types.Int64Type,
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *todoOrphansDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	tflog.Debug(ctx, "Preparing to read todo orphans data source")
+	var state todoOrphansDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	minAge, diags := parseOrphanMinAge(state.MinAge)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	orphans, err := findOrphanTodos(ctx, d.client, minAge)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Todos",
+			err.Error(),
+		)
+		return
+	}
+
+	// Map response body to model
+	state.Orphans = []todoOrphanModel{}
+	state.IDs = []types.Int64{}
+	for _, orphan := range orphans {
+		// Orphans are listed even if their description cannot be decrypted
+		description, err := d.keyring.decrypt(orphan.description)
+		if err != nil {
+			description = orphan.description
+		}
+
+		state.Orphans = append(state.Orphans, todoOrphanModel{
+			ID:               types.Int64Value(orphan.item.ID),
+			Description:      types.StringValue(description),
+			Completed:        types.BoolValue(orphan.item.Completed != nil && *orphan.item.Completed),
+			IdempotencyToken: types.StringValue(orphan.token),
+			CreatedAt:        types.StringValue(orphan.started.Format(time.RFC3339)),
+		})
+		state.IDs = append(state.IDs, types.Int64Value(orphan.item.ID))
+	}
+
+	// Set state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	tflog.Debug(ctx, "Finished reading todo orphans data source", map[string]any{"success": true})
+}
+
+// parseOrphanMinAge parses a min_age attribute, which defaults to
+// defaultOrphanMinAge.
+func parseOrphanMinAge(value types.String) (time.Duration, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if value.IsNull() {
+		return defaultOrphanMinAge, diags
+	}
+
+	minAge, err := time.ParseDuration(value.ValueString())
+	if err != nil || minAge < 0 {
+		diags.AddAttributeError(
+			path.Root("min_age"),
+			"Invalid Minimum Age",
+			"The min_age must be a duration such as '10m' or '0s', got: '"+value.ValueString()+"'.",
+		)
+	}
+	return minAge, diags
+}
@@ -0,0 +1,35 @@
+package todo
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccTodoOrphansDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: providerConfig + `
+resource "todo_todo" "test" {
+	description = "Go Shopping"
+	completed = false
+}
+
+data "todo_orphans" "test" {
+	min_age = "0s"
+
+	depends_on = [todo_todo.test]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					// Finished creates leave no orphans behind
+					resource.TestCheckResourceAttr("data.todo_orphans.test", "orphans.#", "0"),
+					resource.TestCheckResourceAttr("data.todo_orphans.test", "ids.#", "0"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,82 @@
+package todo
+
+import (
+	"context"
+	"errors"
+	"math"
+
+	// Todo API Libraries
+	"github.com/spkane/todo-for-terraform/client"
+	"github.com/spkane/todo-for-terraform/client/todos"
+	"github.com/spkane/todo-for-terraform/models"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// todoPageSize is the number of todos requested per FindTodos call.
+const todoPageSize int32 = 100
+
+// forEachTodo pages through the todos on the server with an ID above since
+// (zero for all of them) using FindTodos, calling fn once for each one until
+// fn returns false.
+//
+// The Todo server returns up to limit todos with an ID above since, but in
+// no particular order, so a full page may leave out todos below the highest
+// ID it contains. Pages in ascending ID order move the cursor forward to the
+// highest ID seen. Otherwise the same page is requested again with a larger
+// limit, because only a short page is known to hold every remaining todo.
+func forEachTodo(ctx context.Context, c *client.TodoList, since int64, fn func(*models.Item) bool) error {
+	limit := todoPageSize
+	seen := make(map[int64]bool)
+
+	for page := 1; ; page++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		params := todos.NewFindTodosParams()
+		params.SetSince(&since)
+		params.SetLimit(&limit)
+		result, err := c.Todos.FindTodos(params)
+		if err != nil {
+			return err
+		}
+
+		items := result.GetPayload()
+		tflog.Trace(ctx, "Read page of todos", map[string]any{
+			"page":  page,
+			"since": since,
+			"limit": limit,
+			"count": len(items)})
+
+		cursor, ascending := since, true
+		for _, item := range items {
+			if item == nil {
+				continue
+			}
+			if item.ID > cursor {
+				cursor = item.ID
+			} else {
+				ascending = false
+			}
+			if seen[item.ID] {
+				continue
+			}
+			seen[item.ID] = true
+			if !fn(item) {
+				return nil
+			}
+		}
+
+		switch {
+		case len(items) < int(limit):
+			return nil
+		case ascending:
+			since = cursor
+		case limit > math.MaxInt32/2:
+			return errors.New("too many todos to page through an unordered FindTodos response")
+		default:
+			limit *= 2
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package todo
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strconv"
+	"testing"
+
+	"github.com/spkane/todo-for-terraform/models"
+)
+
+// newTestTodoListHandler serves FindTodos for todos 1 to n the way the Todo
+// server does, optionally returning each page in descending ID order.
+func newTestTodoListHandler(t *testing.T, n int64, descending bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		since, _ := strconv.ParseInt(r.URL.Query().Get("since"), 10, 64)
+		limit, err := strconv.Atoi(r.URL.Query().Get("limit"))
+		if err != nil {
+			t.Errorf("unexpected limit: %v", err)
+		}
+
+		ids := make([]int64, 0)
+		for id := n; id > since; id-- {
+			ids = append(ids, id)
+		}
+		if !descending {
+			sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+		}
+		if len(ids) > limit {
+			ids = ids[:limit]
+		}
+
+		items := make([]map[string]any, 0, len(ids))
+		for _, id := range ids {
+			items = append(items, map[string]any{"id": id, "description": "todo " + strconv.FormatInt(id, 10), "completed": false})
+		}
+		w.Header().Set("Content-Type", "application/spkane.todo-list.v1+json")
+		_ = json.NewEncoder(w).Encode(items)
+	}
+}
+
+func TestForEachTodo(t *testing.T) {
+	for name, descending := range map[string]bool{"ascending": false, "unordered": true} {
+		name, descending := name, descending
+		t.Run(name, func(t *testing.T) {
+			c := newTestTodoClient(t, newTestTodoListHandler(t, 250, descending))
+
+			seen := make(map[int64]int)
+			err := forEachTodo(context.Background(), c, 20, func(item *models.Item) bool {
+				seen[item.ID]++
+				return true
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(seen) != 230 {
+				t.Fatalf("expected 230 todos, got %d", len(seen))
+			}
+			for id, count := range seen {
+				if id <= 20 || count != 1 {
+					t.Fatalf("todo %d seen %d times", id, count)
+				}
+			}
+		})
+	}
+}
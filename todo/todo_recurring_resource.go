@@ -0,0 +1,620 @@
+package todo
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	// recurringCurrentKey is the sync key of the current instance of a
+	// recurring todo.
+	recurringCurrentKey = "current"
+	// recurringHistoryKey is the sync key of past instances of a recurring
+	// todo.
+	recurringHistoryKey = "history"
+
+	// recurringDueMargin is how close to falling due an instance must be for
+	// a plan that changes the recurring todo to leave the next instance up to
+	// the apply, as it may fall due in between.
+	recurringDueMargin = 10 * time.Minute
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                   = &todoRecurringResource{}
+	_ resource.ResourceWithConfigure      = &todoRecurringResource{}
+	_ resource.ResourceWithValidateConfig = &todoRecurringResource{}
+	_ resource.ResourceWithModifyPlan     = &todoRecurringResource{}
+)
+
+// NewTodoRecurringResource is a helper function to simplify the provider implementation.
+func NewTodoRecurringResource() resource.Resource {
+	return &todoRecurringResource{}
+}
+
+// todoRecurringResource is the resource implementation.
+type todoRecurringResource struct {
+	writer *todoWriter
+}
+
+// todoRecurringResourceModel maps the resource schema data.
+type todoRecurringResourceModel struct {
+	ID                  types.String `tfsdk:"id"`
+	DescriptionTemplate types.String `tfsdk:"description_template"`
+	Schedule            types.String `tfsdk:"schedule"`
+	Interval            types.String `tfsdk:"interval"`
+	HistoryLimit        types.Int64  `tfsdk:"history_limit"`
+	Occurrence          types.Int64  `tfsdk:"occurrence"`
+	CurrentID           types.Int64  `tfsdk:"current_id"`
+	CurrentDescription  types.String `tfsdk:"current_description"`
+	CurrentCompleted    types.Bool   `tfsdk:"current_completed"`
+	CurrentCreatedAt    types.String `tfsdk:"current_created_at"`
+	NextDue             types.String `tfsdk:"next_due"`
+	HistoryIDs          types.List   `tfsdk:"history_ids"`
+}
+
+// recurringDescriptionData is the data available to description templates.
+type recurringDescriptionData struct {
+	Occurrence int64
+	Date       string
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *todoRecurringResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	r.writer = newTodoWriter(req.ProviderData.(*todoProviderData))
+}
+
+// Metadata returns the resource type name.
+func (r *todoRecurringResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_recurring"
+}
+
+// Schema defines the schema for the resource.
+func (r *todoRecurringResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "A todo that comes back. A new instance is created on the next apply once the current one is completed " +
+			"or deleted, or once the schedule says it is due. Past instances are kept up to the history limit. " +
+			"A plan that changes the recurring todo within 10 minutes of the next instance falling due leaves the instance attributes unknown, " +
+			"and the apply creates the next instance if it is due by then.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "A unique identifier for the recurring todo.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"description_template": schema.StringAttribute{
+				Description: "A Go template rendered into the description of each instance. It can use `{{ .Occurrence }}`, " +
+					"the number of the instance starting at 1, and `{{ .Date }}`, the UTC date the instance was created.",
+				Required: true,
+			},
+			"schedule": schema.StringAttribute{
+				Description: "A cron expression in UTC, such as `0 9 * * 1`, or a macro such as `@daily`. A new instance is due at " +
+					"the first matching time after the current one was created. Conflicts with `interval`.",
+				Optional: true,
+			},
+			"interval": schema.StringAttribute{
+				Description: "A duration such as `720h`. A new instance is due this long after the current one was created. " +
+					"Conflicts with `schedule`.",
+				Optional: true,
+			},
+			"history_limit": schema.Int64Attribute{
+				Description: "How many past instances to keep. Older ones are deleted. Past instances are kept without limit if unset.",
+				Optional:    true,
+			},
+			"occurrence": schema.Int64Attribute{
+				Description: "The number of the current instance, starting at 1.",
+				Computed:    true,
+			},
+			"current_id": schema.Int64Attribute{
+				Description: "The unique identifier of the current instance.",
+				Computed:    true,
+			},
+			"current_description": schema.StringAttribute{
+				Description: "The description of the current instance.",
+				Computed:    true,
+			},
+			"current_completed": schema.BoolAttribute{
+				Description: "The completed status of the current instance.",
+				Computed:    true,
+			},
+			"current_created_at": schema.StringAttribute{
+				Description: "When the current instance was created, in RFC 3339 format.",
+				Computed:    true,
+			},
+			"next_due": schema.StringAttribute{
+				Description: "When the next instance is due according to the schedule, in RFC 3339 format.",
+				Computed:    true,
+			},
+			"history_ids": schema.ListAttribute{
+				Description: "The unique identifiers of the past instances that are kept, oldest first.",
+				Computed:    true,
+				ElementType: types.Int64Type,
+			},
+		},
+	}
+}
+
+// ValidateConfig checks the description template, schedule and history
+// limit.
+func (r *todoRecurringResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config todoRecurringResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !config.DescriptionTemplate.IsUnknown() {
+		if _, err := renderRecurringDescription(config.DescriptionTemplate.ValueString(), 1, time.Now()); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("description_template"),
+				"Invalid Description Template",
+				"The description_template is not a valid template: "+err.Error(),
+			)
+		}
+	}
+	if !config.Schedule.IsUnknown() && !config.Interval.IsUnknown() {
+		if _, err := parseRecurrenceSchedule(config.Schedule.ValueString(), config.Interval.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("schedule"),
+				"Invalid Recurrence Schedule",
+				err.Error(),
+			)
+		}
+	}
+	if !config.HistoryLimit.IsUnknown() && !config.HistoryLimit.IsNull() && config.HistoryLimit.ValueInt64() < 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("history_limit"),
+			"Invalid History Limit",
+			"The history_limit must be at least 0.",
+		)
+	}
+}
+
+// ModifyPlan plans a new instance once the current one is completed or
+// deleted, or once the schedule says it is due. Otherwise the current
+// instance is kept, with its description rendered from the template again.
+//
+// When the plan changes the recurring todo anyway and the current instance
+// falls due within recurringDueMargin, the occurrence and current instance
+// are left unknown and Update decides whether it is due, so the apply does
+// not plan differently.
+func (r *todoRecurringResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to plan when the resource is being destroyed
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan todoRecurringResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// A new recurring todo starts with its first instance
+	if req.State.Raw.IsNull() {
+		plan.Occurrence = types.Int64Value(1)
+		plan.CurrentCompleted = types.BoolValue(false)
+		plan.HistoryIDs = types.ListValueMust(types.Int64Type, nil)
+		resp.Diagnostics.Append(resp.Plan.Set(ctx, plan)...)
+		return
+	}
+
+	var state todoRecurringResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var history []int64
+	resp.Diagnostics.Append(state.HistoryIDs.ElementsAs(ctx, &history, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	now := time.Now()
+	due, nextDue, diags := plan.planOccurrence(state, now)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if due {
+		tflog.Debug(ctx, "Recurring todo is due", map[string]any{"ID": state.ID.ValueString(), "occurrence": plan.Occurrence.ValueInt64()})
+		if !state.CurrentID.IsNull() {
+			history = append(history, state.CurrentID.ValueInt64())
+		}
+	}
+
+	if plan.HistoryLimit.IsUnknown() {
+		plan.HistoryIDs = types.ListUnknown(types.Int64Type)
+	} else {
+		kept, _ := trimRecurringHistory(history, plan.HistoryLimit)
+		plan.HistoryIDs, diags = types.ListValueFrom(ctx, types.Int64Type, kept)
+		resp.Diagnostics.Append(diags...)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Leave an instance that falls due soon up to the apply, unless nothing
+	// changes and there is no apply
+	if !due && !nextDue.IsZero() && now.Add(recurringDueMargin).After(nextDue) && !resp.Plan.Raw.Equal(req.State.Raw) {
+		tflog.Debug(ctx, "Recurring todo falls due soon, leaving the next instance to the apply", map[string]any{"ID": state.ID.ValueString()})
+		plan.Occurrence = types.Int64Unknown()
+		plan.CurrentID = types.Int64Unknown()
+		plan.CurrentDescription = types.StringUnknown()
+		plan.CurrentCompleted = types.BoolUnknown()
+		plan.CurrentCreatedAt = types.StringUnknown()
+		plan.NextDue = types.StringUnknown()
+		plan.HistoryIDs = types.ListUnknown(types.Int64Type)
+		resp.Diagnostics.Append(resp.Plan.Set(ctx, plan)...)
+	}
+}
+
+// planOccurrence plans the occurrence and current instance as of now, given
+// the prior state: a new instance once the current one is completed or
+// deleted, or once the schedule says it is due, and otherwise the current
+// instance with its description rendered from the template again. It
+// reports whether a new instance is planned and, if the current one is
+// kept, when the next one falls due, or the zero time if none is scheduled
+// or the schedule is unknown.
+func (m *todoRecurringResourceModel) planOccurrence(state todoRecurringResourceModel, now time.Time) (bool, time.Time, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	createdAt, err := time.Parse(time.RFC3339, state.CurrentCreatedAt.ValueString())
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root("current_created_at"),
+			"Invalid Creation Time",
+			"The current_created_at in state is not an RFC 3339 time: "+err.Error(),
+		)
+		return false, time.Time{}, diags
+	}
+
+	due := state.CurrentID.IsNull() || state.CurrentCompleted.ValueBool()
+	scheduleKnown := !m.Schedule.IsUnknown() && !m.Interval.IsUnknown()
+	var nextDue time.Time
+	var scheduled bool
+	if scheduleKnown {
+		schedule, err := parseRecurrenceSchedule(m.Schedule.ValueString(), m.Interval.ValueString())
+		if err != nil {
+			diags.AddAttributeError(path.Root("schedule"), "Invalid Recurrence Schedule", err.Error())
+			return false, time.Time{}, diags
+		}
+		nextDue, scheduled = schedule.next(createdAt)
+		if scheduled && !now.Before(nextDue) {
+			due = true
+		}
+	}
+
+	if due {
+		m.Occurrence = types.Int64Value(state.Occurrence.ValueInt64() + 1)
+		m.CurrentID = types.Int64Unknown()
+		m.CurrentDescription = types.StringUnknown()
+		m.CurrentCompleted = types.BoolValue(false)
+		m.CurrentCreatedAt = types.StringUnknown()
+		m.NextDue = types.StringUnknown()
+		return true, time.Time{}, diags
+	}
+
+	m.Occurrence = state.Occurrence
+	m.CurrentID = state.CurrentID
+	m.CurrentCompleted = state.CurrentCompleted
+	m.CurrentCreatedAt = state.CurrentCreatedAt
+	m.CurrentDescription = types.StringUnknown()
+	if !m.DescriptionTemplate.IsUnknown() {
+		description, err := renderRecurringDescription(m.DescriptionTemplate.ValueString(), m.Occurrence.ValueInt64(), createdAt)
+		if err != nil {
+			diags.AddAttributeError(path.Root("description_template"), "Invalid Description Template", err.Error())
+			return false, time.Time{}, diags
+		}
+		m.CurrentDescription = types.StringValue(description)
+	}
+	switch {
+	case !scheduleKnown:
+		m.NextDue = types.StringUnknown()
+	case scheduled:
+		m.NextDue = types.StringValue(nextDue.Format(time.RFC3339))
+	default:
+		m.NextDue = types.StringNull()
+		nextDue = time.Time{}
+	}
+	return false, nextDue, diags
+}
+
+// Create creates the first instance.
+func (r *todoRecurringResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	tflog.Debug(ctx, "Preparing to create todo recurring resource")
+	// Retrieve values from plan
+	var plan todoRecurringResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id, err := uuid.GenerateUUID()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating todo",
+			"Could not generate an ID, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	plan.ID = types.StringValue(id)
+
+	resp.Diagnostics.Append(r.createInstance(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	tflog.Debug(ctx, "Created todo recurring resource", map[string]any{"success": !resp.Diagnostics.HasError()})
+}
+
+// Read refreshes the current instance and drops past instances that no
+// longer exist. A completed or deleted current instance is replaced on the
+// next apply.
+func (r *todoRecurringResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	tflog.Debug(ctx, "Preparing to read todo recurring resource")
+	// Get current state
+	var state todoRecurringResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var history []int64
+	resp.Diagnostics.Append(state.HistoryIDs.ElementsAs(ctx, &history, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	entries := make([]todoSyncEntry, 0, len(history)+1)
+	if !state.CurrentID.IsNull() {
+		entries = append(entries, todoSyncEntry{
+			Key:         recurringCurrentKey,
+			ID:          state.CurrentID.ValueInt64(),
+			Description: state.CurrentDescription.ValueString(),
+		})
+	}
+	for _, id := range history {
+		entries = append(entries, todoSyncEntry{Key: recurringHistoryKey, ID: id})
+	}
+
+	entries, err := r.writer.scan(ctx, entries)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Todos",
+			"Could not read recurring todo "+state.ID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	state.CurrentID = types.Int64Null()
+	history = history[:0]
+	for _, entry := range entries {
+		if entry.Key == recurringHistoryKey {
+			history = append(history, entry.ID)
+			continue
+		}
+		state.CurrentID = types.Int64Value(entry.ID)
+		state.CurrentDescription = types.StringValue(entry.Description)
+		state.CurrentCompleted = types.BoolValue(entry.Completed)
+	}
+	if state.CurrentID.IsNull() {
+		tflog.Debug(ctx, "Current instance of recurring todo no longer exists", map[string]any{"ID": state.ID.ValueString()})
+	}
+	state.HistoryIDs, diags = types.ListValueFrom(ctx, types.Int64Type, history)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	tflog.Debug(ctx, "Finished reading todo recurring resource", map[string]any{"success": true})
+}
+
+// Update creates the next instance when one is planned, or otherwise writes
+// the rendered description to the current instance. Past instances beyond
+// the history limit are deleted.
+func (r *todoRecurringResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	tflog.Debug(ctx, "Preparing to update todo recurring resource")
+	// Retrieve values from plan and state
+	var plan, state todoRecurringResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var history []int64
+	resp.Diagnostics.Append(state.HistoryIDs.ElementsAs(ctx, &history, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Decide whether the next instance is due if the plan left it open
+	if plan.Occurrence.IsUnknown() {
+		_, _, diags := plan.planOccurrence(state, time.Now())
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	if plan.CurrentID.IsUnknown() {
+		if !state.CurrentID.IsNull() {
+			history = append(history, state.CurrentID.ValueInt64())
+		}
+		resp.Diagnostics.Append(r.createInstance(ctx, &plan)...)
+	} else if plan.CurrentDescription.ValueString() != state.CurrentDescription.ValueString() {
+		_, err := r.writer.update(ctx, plan.CurrentID.ValueInt64(), plan.CurrentDescription.ValueString(), plan.CurrentCompleted.ValueBool())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Updating Todo",
+				"Could not update todo ID "+plan.CurrentID.String()+", unexpected error: "+err.Error(),
+			)
+		}
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Delete past instances beyond the history limit, keeping any that
+	// could not be deleted so the next apply tries again
+	kept, expired := trimRecurringHistory(history, plan.HistoryLimit)
+	var undeleted []int64
+	var failed []string
+	for _, id := range expired {
+		if err := r.writer.delete(id); err != nil {
+			undeleted = append(undeleted, id)
+			failed = append(failed, "todo ID "+strconv.FormatInt(id, 10)+": "+err.Error())
+		}
+	}
+	var diags diag.Diagnostics
+	plan.HistoryIDs, diags = types.ListValueFrom(ctx, types.Int64Type, append(undeleted, kept...))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if len(failed) > 0 {
+		resp.Diagnostics.AddError(
+			"Error Deleting Todo",
+			"Could not delete past instances:\n\n"+strings.Join(failed, "\n"),
+		)
+	}
+	tflog.Debug(ctx, "Updated todo recurring resource", map[string]any{"success": !resp.Diagnostics.HasError()})
+}
+
+// Delete deletes the current instance and the past instances that are kept.
+func (r *todoRecurringResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	tflog.Debug(ctx, "Preparing to delete todo recurring resource")
+	// Retrieve values from state
+	var state todoRecurringResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var ids []int64
+	resp.Diagnostics.Append(state.HistoryIDs.ElementsAs(ctx, &ids, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !state.CurrentID.IsNull() {
+		ids = append(ids, state.CurrentID.ValueInt64())
+	}
+
+	for _, id := range ids {
+		if err := r.writer.delete(id); err != nil {
+			resp.Diagnostics.AddError(
+				"Error Deleting Todo",
+				"Could not delete todo ID "+strconv.FormatInt(id, 10)+", unexpected error: "+err.Error(),
+			)
+		}
+	}
+	tflog.Debug(ctx, "Deleted todo recurring resource", map[string]any{"success": !resp.Diagnostics.HasError()})
+}
+
+// createInstance creates the instance numbered by the planned occurrence and
+// records it as the current one.
+func (r *todoRecurringResource) createInstance(ctx context.Context, plan *todoRecurringResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	createdAt := time.Now().UTC().Truncate(time.Second)
+	description, err := renderRecurringDescription(plan.DescriptionTemplate.ValueString(), plan.Occurrence.ValueInt64(), createdAt)
+	if err != nil {
+		diags.AddAttributeError(path.Root("description_template"), "Invalid Description Template", err.Error())
+		return diags
+	}
+	schedule, err := parseRecurrenceSchedule(plan.Schedule.ValueString(), plan.Interval.ValueString())
+	if err != nil {
+		diags.AddAttributeError(path.Root("schedule"), "Invalid Recurrence Schedule", err.Error())
+		return diags
+	}
+
+	todo, err := r.writer.create(ctx, description, false)
+	if err != nil {
+		diags.AddError(
+			"Error creating todo",
+			"Could not create instance "+plan.Occurrence.String()+" of recurring todo "+plan.ID.ValueString()+
+				", unexpected error: "+err.Error(),
+		)
+		return diags
+	}
+	tflog.Info(ctx, "Created recurring todo instance", map[string]any{"ID": todo.ID, "occurrence": plan.Occurrence.ValueInt64()})
+
+	plan.CurrentID = types.Int64Value(todo.ID)
+	plan.CurrentDescription = types.StringValue(description)
+	plan.CurrentCompleted = types.BoolValue(false)
+	plan.CurrentCreatedAt = types.StringValue(createdAt.Format(time.RFC3339))
+	plan.NextDue = types.StringNull()
+	if nextDue, ok := schedule.next(createdAt); ok {
+		plan.NextDue = types.StringValue(nextDue.Format(time.RFC3339))
+	}
+	return diags
+}
+
+// renderRecurringDescription renders the description of an instance.
+func renderRecurringDescription(text string, occurrence int64, createdAt time.Time) (string, error) {
+	tmpl, err := template.New("description").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", err
+	}
+
+	var description strings.Builder
+	err = tmpl.Execute(&description, recurringDescriptionData{
+		Occurrence: occurrence,
+		Date:       createdAt.UTC().Format(time.DateOnly),
+	})
+	return description.String(), err
+}
+
+// trimRecurringHistory splits past instances, oldest first, into the ones a
+// history limit keeps and the older ones it expires. A null limit keeps
+// every instance.
+func trimRecurringHistory(history []int64, limit types.Int64) (kept, expired []int64) {
+	kept = history
+	if !limit.IsNull() && int64(len(history)) > limit.ValueInt64() {
+		split := len(history) - int(limit.ValueInt64())
+		kept, expired = history[split:], history[:split]
+	}
+	if kept == nil {
+		kept = []int64{}
+	}
+	return kept, expired
+}
@@ -0,0 +1,134 @@
+package todo
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccTodoRecurringResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: providerConfig + `
+resource "todo_recurring" "test" {
+	description_template = "Rotate credentials #{{ .Occurrence }}"
+	interval             = "720h"
+	history_limit        = 2
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("todo_recurring.test", "occurrence", "1"),
+					resource.TestCheckResourceAttr("todo_recurring.test", "current_description", "Rotate credentials #1"),
+					resource.TestCheckResourceAttr("todo_recurring.test", "current_completed", "false"),
+					resource.TestCheckResourceAttr("todo_recurring.test", "history_ids.#", "0"),
+					resource.TestCheckResourceAttrSet("todo_recurring.test", "current_id"),
+					resource.TestCheckResourceAttrSet("todo_recurring.test", "next_due"),
+				),
+			},
+			// Update and Read testing
+			{
+				Config: providerConfig + `
+resource "todo_recurring" "test" {
+	description_template = "Rotate API credentials #{{ .Occurrence }}"
+	interval             = "720h"
+	history_limit        = 2
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("todo_recurring.test", "occurrence", "1"),
+					resource.TestCheckResourceAttr("todo_recurring.test", "current_description", "Rotate API credentials #1"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestRenderRecurringDescription(t *testing.T) {
+	description, err := renderRecurringDescription("Backup #{{ .Occurrence }} ({{ .Date }})", 3, time.Date(2024, 5, 15, 23, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if description != "Backup #3 (2024-05-15)" {
+		t.Errorf("unexpected description %q", description)
+	}
+
+	if _, err := renderRecurringDescription("{{ .Week }}", 1, time.Now()); err == nil {
+		t.Error("expected an unknown field to be an error")
+	}
+}
+
+func TestTrimRecurringHistory(t *testing.T) {
+	testCases := []struct {
+		scenario string
+		history  []int64
+		limit    types.Int64
+		kept     []int64
+		expired  []int64
+	}{
+		{"no limit", []int64{1, 2, 3}, types.Int64Null(), []int64{1, 2, 3}, nil},
+		{"under the limit", []int64{1, 2, 3}, types.Int64Value(5), []int64{1, 2, 3}, nil},
+		{"over the limit", []int64{1, 2, 3}, types.Int64Value(1), []int64{3}, []int64{1, 2}},
+		{"no history", []int64{1, 2, 3}, types.Int64Value(0), []int64{}, []int64{1, 2, 3}},
+		{"empty", nil, types.Int64Value(2), []int64{}, nil},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.scenario, func(t *testing.T) {
+			kept, expired := trimRecurringHistory(testCase.history, testCase.limit)
+			if !reflect.DeepEqual(kept, testCase.kept) || !reflect.DeepEqual(expired, testCase.expired) {
+				t.Errorf("expected %v and %v, got %v and %v", testCase.kept, testCase.expired, kept, expired)
+			}
+		})
+	}
+}
+
+func TestRecurringPlanOccurrence(t *testing.T) {
+	createdAt := time.Date(2024, 5, 15, 9, 0, 0, 0, time.UTC)
+	state := todoRecurringResourceModel{
+		DescriptionTemplate: types.StringValue("Backup #{{ .Occurrence }}"),
+		Schedule:            types.StringNull(),
+		Interval:            types.StringValue("1h"),
+		Occurrence:          types.Int64Value(2),
+		CurrentID:           types.Int64Value(7),
+		CurrentDescription:  types.StringValue("Backup #2"),
+		CurrentCompleted:    types.BoolValue(false),
+		CurrentCreatedAt:    types.StringValue(createdAt.Format(time.RFC3339)),
+	}
+
+	testCases := []struct {
+		scenario   string
+		now        time.Time
+		completed  bool
+		due        bool
+		occurrence int64
+		nextDue    time.Time
+	}{
+		{"not due", createdAt.Add(30 * time.Minute), false, false, 2, createdAt.Add(time.Hour)},
+		{"due", createdAt.Add(time.Hour), false, true, 3, time.Time{}},
+		{"completed", createdAt.Add(time.Minute), true, true, 3, time.Time{}},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.scenario, func(t *testing.T) {
+			state := state
+			state.CurrentCompleted = types.BoolValue(testCase.completed)
+			plan := state
+
+			due, nextDue, diags := plan.planOccurrence(state, testCase.now)
+			if diags.HasError() {
+				t.Fatalf("unexpected errors: %v", diags)
+			}
+			if due != testCase.due || !nextDue.Equal(testCase.nextDue) || plan.Occurrence.ValueInt64() != testCase.occurrence {
+				t.Errorf("expected %v, %v and occurrence %d, got %v, %v and %v", testCase.due, testCase.nextDue, testCase.occurrence, due, nextDue, plan.Occurrence)
+			}
+			if due != plan.CurrentID.IsUnknown() {
+				t.Errorf("expected the current instance to be replaced exactly when due, got %v", plan.CurrentID)
+			}
+		})
+	}
+}
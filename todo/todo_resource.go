@@ -3,12 +3,14 @@ package todo
 import (
 	"context"
 	"strconv"
+	"time"
 
 	// Todo API Libraries
 	"github.com/spkane/todo-for-terraform/client"
 	"github.com/spkane/todo-for-terraform/client/todos"
 	"github.com/spkane/todo-for-terraform/models"
 
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -20,26 +22,42 @@ import (
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource                = &todoResource{}
-	_ resource.ResourceWithConfigure   = &todoResource{}
-	_ resource.ResourceWithImportState = &todoResource{}
+	_ resource.Resource                   = &todoResource{}
+	_ resource.ResourceWithConfigure      = &todoResource{}
+	_ resource.ResourceWithImportState    = &todoResource{}
+	_ resource.ResourceWithModifyPlan     = &todoResource{}
+	_ resource.ResourceWithValidateConfig = &todoResource{}
 )
 
 // NewTodoResource is a helper function to simplify the provider implementation.
-func NewTodoResource() resource.Resource {
-	return &todoResource{}
+func NewTodoResource(normalizers *descriptionNormalizers) resource.Resource {
+	return &todoResource{
+		descriptionNormalizers: normalizers,
+	}
 }
 
 // todoResource is the resource implementation.
 type todoResource struct {
-	client *client.TodoList
+	client                 *client.TodoList
+	writer                 *todoWriter
+	idReuseAction          string
+	keyring                *todoKeyring
+	consistencyTimeout     time.Duration
+	descriptionNormalizer  *descriptionNormalizer
+	descriptionNormalizers *descriptionNormalizers
 }
 
 // todoResourceModel maps the resource schema data.
 type todoResourceModel struct {
-	ID          types.Int64  `tfsdk:"id"`
-	Description types.String `tfsdk:"description"`
-	Completed   types.Bool   `tfsdk:"completed"`
+	ID                  types.Int64      `tfsdk:"id"`
+	Description         descriptionValue `tfsdk:"description"`
+	Completed           types.Bool       `tfsdk:"completed"`
+	ServerCompleted     types.Bool       `tfsdk:"server_completed"`
+	Revision            types.Int64      `tfsdk:"revision"`
+	PreviousDescription types.String     `tfsdk:"previous_description"`
+	ExpiresAt           types.String     `tfsdk:"expires_at"`
+	OnExpiry            types.String     `tfsdk:"on_expiry"`
+	Expired             types.Bool       `tfsdk:"expired"`
 }
 
 // Configure adds the provider configured client to the resource.
@@ -48,7 +66,13 @@ func (r *todoResource) Configure(_ context.Context, req resource.ConfigureReques
 		return
 	}
 
-	r.client = req.ProviderData.(*client.TodoList)
+	providerData := req.ProviderData.(*todoProviderData)
+	r.client = providerData.client
+	r.writer = newTodoWriter(providerData)
+	r.idReuseAction = providerData.idReuseAction
+	r.keyring = providerData.keyring
+	r.consistencyTimeout = providerData.consistencyTimeout
+	r.descriptionNormalizer = providerData.descriptionNormalizer
 }
 
 // Metadata returns the resource type name.
@@ -69,17 +93,140 @@ func (r *todoResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 				},
 			},
 			"description": schema.StringAttribute{
-				Description: "The description for the todo.",
+				Description: "The description for the todo. Differences that the provider's description_normalization setting ignores are not reported as drift.",
 				Required:    true,
+				CustomType: descriptionType{
+					normalizers: r.descriptionNormalizers,
+				},
 			},
 			"completed": schema.BoolAttribute{
 				Description: "The completed status for the todo.",
 				Required:    true,
 			},
+			"server_completed": schema.BoolAttribute{
+				Description: "The completed status the todo has on the Todo server, read back after every apply and refresh. " +
+					"It differs from completed once the todo is completed on expiry, and is null once it is deleted on expiry.",
+				Computed: true,
+			},
+			"revision": schema.Int64Attribute{
+				Description: "The revision of the todo, starting at 1 and increased every time Terraform changes its description or completed status.",
+				Computed:    true,
+			},
+			"previous_description": schema.StringAttribute{
+				Description: "The description the todo had before Terraform last changed it.",
+				Computed:    true,
+			},
+			"expires_at": schema.StringAttribute{
+				Description: "When the todo expires, in RFC 3339 format. The first plan after this time applies on_expiry. " +
+					"A plan that changes the todo within 10 minutes of it expiring leaves applying on_expiry to the apply.",
+				Optional: true,
+			},
+			"on_expiry": schema.StringAttribute{
+				Description: "What happens to the todo once it expires: `complete` (default) completes it on the server while " +
+					"`completed` keeps its configured value, and `delete` deletes it while keeping the resource with a null `id`. Moving " +
+					"`expires_at` into the future brings a deleted todo back as a new todo.",
+				Optional: true,
+			},
+			"expired": schema.BoolAttribute{
+				Description: "Whether the todo has expired. It is known when planning, so it can be used in conditions, " +
+					"unless the plan changes the todo within 10 minutes of it expiring.",
+				Computed: true,
+			},
 		},
 	}
 }
 
+// ValidateConfig checks the expiry attributes.
+func (r *todoResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config todoResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(validateExpiry(config.ExpiresAt, config.OnExpiry)...)
+}
+
+// ModifyPlan predicts the revision attributes, which only change when
+// Terraform changes the description or completed status. It also compares
+// the current time to the expiry, so the first plan after it plans the
+// todo to be completed or deleted. A plan that changes a todo about to
+// expire leaves it to the apply, so the apply does not plan differently.
+func (r *todoResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to plan on destroy
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan todoResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	now := time.Now()
+	plan.Expired = planExpired(plan.ExpiresAt, now)
+	if req.State.Raw.IsNull() {
+		plan.Revision = types.Int64Value(1)
+		plan.PreviousDescription = types.StringNull()
+	} else {
+		var state todoResourceModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		planRevision(state, &plan)
+
+		// A todo deleted on expiry can only come back as a new todo
+		if state.deletedByExpiry() && !plan.deletedByExpiry() {
+			resp.RequiresReplace = append(resp.RequiresReplace, path.Root("expired"))
+		}
+	}
+	if plan.deletedByExpiry() {
+		plan.ID = types.Int64Null()
+	}
+	plan.ServerCompleted = plan.planServerCompleted()
+
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Leave a todo that expires soon to the apply, unless nothing changes
+	// and there is no apply
+	if expiresSoon(plan.ExpiresAt, now) && !resp.Plan.Raw.Equal(req.State.Raw) {
+		plan.Expired = types.BoolUnknown()
+		plan.ServerCompleted = types.BoolUnknown()
+		if expiryAction(plan.OnExpiry) == expiryActionDelete {
+			plan.ID = types.Int64Unknown()
+		}
+		resp.Diagnostics.Append(resp.Plan.Set(ctx, plan)...)
+	}
+}
+
+// planRevision sets the revision attributes of plan for an update from state.
+func planRevision(state todoResourceModel, plan *todoResourceModel) {
+	if plan.Description.IsUnknown() || plan.Completed.IsUnknown() {
+		plan.Revision = types.Int64Unknown()
+		plan.PreviousDescription = types.StringUnknown()
+		return
+	}
+
+	plan.Revision = state.Revision
+	if plan.Revision.IsNull() {
+		plan.Revision = types.Int64Value(1)
+	}
+	plan.PreviousDescription = state.PreviousDescription
+
+	descriptionChanged := plan.Description.ValueString() != state.Description.ValueString()
+	if descriptionChanged || plan.Completed.ValueBool() != state.Completed.ValueBool() {
+		plan.Revision = types.Int64Value(plan.Revision.ValueInt64() + 1)
+	}
+	if descriptionChanged {
+		plan.PreviousDescription = types.StringValue(state.Description.ValueString())
+	}
+}
+
 func (r *todoResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	// Retrieve import ID and save to id attribute
 	// If our ID was a string then we could do this
@@ -107,34 +254,93 @@ func (r *todoResource) Create(ctx context.Context, req resource.CreateRequest, r
 		return
 	}
 
-	description := plan.Description.ValueString()
-	completed := plan.Completed.ValueBool()
+	// A todo that expired before it was created is never created
+	plan.resolveExpired(types.Int64Unknown(), time.Now())
+	if plan.deletedByExpiry() {
+		tflog.Info(ctx, "Not creating todo that has already expired", map[string]any{"expires_at": plan.ExpiresAt.ValueString()})
+		plan.ID = types.Int64Null()
+		plan.ServerCompleted = types.BoolNull()
+		plan.Revision = types.Int64Value(1)
+		plan.PreviousDescription = types.StringNull()
+		diags = resp.State.Set(ctx, plan)
+		resp.Diagnostics.Append(diags...)
+		tflog.Debug(ctx, "Created todo resource", map[string]any{"success": !resp.Diagnostics.HasError()})
+		return
+	}
 
-	todo := models.Item{
-		ID:          int64(0),
+	description, err := r.keyring.encrypt(plan.Description.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating todo",
+			"Could not encrypt todo description, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	completed := plan.serverCompleted()
+
+	// Create new todo, or adopt the one an earlier create left behind
+	id, err := r.writer.addOrAdopt(ctx, description, completed, func(stored string) bool {
+		plaintext, err := r.keyring.decrypt(stored)
+		return err == nil && plaintext == plan.Description.ValueString()
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating todo",
+			"Could not create todo, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	// Remove the pending marker now that we know the todo's ID
+	finished := models.Item{
 		Description: &description,
 		Completed:   &completed,
 	}
+	updateParams := todos.NewUpdateOneParams()
+	updateParams.SetBody(&finished)
+	updateParams.SetID(id)
+	_, err = r.client.Todos.UpdateOne(updateParams)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating todo",
+			"Could not finish creating todo ID "+strconv.FormatInt(id, 10)+", it is left behind as an orphan "+
+				"that the todo_orphans data source lists: "+err.Error(),
+		)
+		return
+	}
 
-	params := todos.NewAddOneParams()
-	params.SetBody(&todo)
-
-	// Create new todo
-	result, err := r.client.Todos.AddOne(params)
+	// Wait for the new todo to read back as written
+	created, err := waitForTodo(ctx, r.client, id, r.consistencyTimeout, r.matchesPlan(plan))
 	if err != nil {
+		// The todo exists, so track it to avoid creating a duplicate
+		plan.ID = types.Int64Value(id)
+		plan.ServerCompleted = types.BoolValue(completed)
+		plan.Revision = types.Int64Value(1)
+		plan.PreviousDescription = types.StringNull()
+		diags = resp.State.Set(ctx, plan)
+		resp.Diagnostics.Append(diags...)
 		resp.Diagnostics.AddError(
 			"Error creating todo",
-			"Could not create todo, unexpected error: "+err.Error(),
+			"Could not read back new todo ID "+strconv.FormatInt(id, 10)+": "+err.Error(),
 		)
 		return
 	}
 
 	// Map response body to schema and populate Computed attribute values
-	resultDescription := result.GetPayload().Description
-	resultCompleted := result.GetPayload().Completed
-	plan.ID = types.Int64Value(result.GetPayload().ID)
-	plan.Description = types.StringValue(*resultDescription)
-	plan.Completed = types.BoolValue(*resultCompleted)
+	resultDescription, diags := r.keyring.decryptDescription(created.ID, *created.Description)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resultCompleted := created.Completed
+	plan.ID = types.Int64Value(created.ID)
+	plan.Description = newDescriptionValue(resultDescription)
+	if !plan.completedByExpiry() {
+		plan.Completed = types.BoolValue(*resultCompleted)
+	}
+	plan.ServerCompleted = types.BoolValue(*resultCompleted)
+	plan.Revision = types.Int64Value(1)
+	plan.PreviousDescription = types.StringNull()
 
 	// Set state to fully populated data
 	diags = resp.State.Set(ctx, plan)
@@ -142,6 +348,35 @@ func (r *todoResource) Create(ctx context.Context, req resource.CreateRequest, r
 	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	// Remember which item we created, so Read can detect ID reuse
+	fingerprint, err := newTodoFingerprint(resultDescription, r.descriptionNormalizer)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating todo",
+			"Could not generate todo fingerprint, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	resp.Diagnostics.Append(setTodoFingerprint(ctx, resp.Private, fingerprint)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Publish the creation run with the revision log, so Read can tell ID
+	// reuse from an edit outside Terraform
+	revisions := &todoRevisionLog{Revision: 1, CreationRun: fingerprint.CreationRun}
+	if err := r.writer.publishRevisionLog(ctx, created.ID, revisions); err != nil {
+		resp.Diagnostics.AddWarning(
+			"Error publishing todo revisions",
+			"Could not publish the revision log of todo ID "+strconv.FormatInt(created.ID, 10)+
+				", so until Terraform next changes it, editing its description outside Terraform looks like ID reuse: "+err.Error(),
+		)
+	}
+	resp.Diagnostics.Append(setTodoRevisionLog(ctx, resp.Private, revisions)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	tflog.Debug(ctx, "Created todo resource", map[string]any{"success": true})
 }
 
@@ -156,6 +391,12 @@ func (r *todoResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		return
 	}
 
+	// Nothing is left on the server once the todo was deleted on expiry
+	if state.deletedByExpiry() {
+		tflog.Debug(ctx, "Todo was deleted on expiry", map[string]any{"ID": state.ID.String()})
+		return
+	}
+
 	// Get refreshed todo value from Todo
 	params := todos.NewFindTodoParams()
 	params.SetID(state.ID.ValueInt64())
@@ -170,11 +411,88 @@ func (r *todoResource) Read(ctx context.Context, req resource.ReadRequest, resp
 
 	todo := result.GetPayload()
 
+	description, diags := r.keyring.decryptDescription(todo[0].ID, *todo[0].Description)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Make sure the server has not handed our ID to an unrelated todo
+	fingerprint, diags := getTodoFingerprint(ctx, req.Private)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	replaced, diags := r.replaced(ctx, req.Private, fingerprint, todo[0].ID, description)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if replaced {
+		if r.idReuseAction == idReuseActionError {
+			resp.Diagnostics.AddError(
+				"Todo Replaced on Server",
+				"The todo with ID "+state.ID.String()+" is no longer the item created in run "+fingerprint.CreationRun+
+					" and appears to be an unrelated todo that reused the ID. "+
+					"Remove it from state with 'terraform state rm' or set the provider id_reuse_action to '"+idReuseActionRemove+"' to recreate it.",
+			)
+			return
+		}
+		tflog.Warn(ctx, "Todo replaced on server, removing from state", map[string]interface{}{
+			"ID":           state.ID.String(),
+			"creation_run": fingerprint.CreationRun})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if fingerprint == nil {
+		// Imported or written by an older provider version, so adopt it
+		fingerprint, err = newTodoFingerprint(description, r.descriptionNormalizer)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Reading Todo",
+				"Could not generate todo fingerprint, unexpected error: "+err.Error(),
+			)
+			return
+		}
+		resp.Diagnostics.Append(setTodoFingerprint(ctx, resp.Private, fingerprint)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// Revisions only change when Terraform updates the todo
+	revision := state.Revision
+	if revision.IsNull() {
+		revision = types.Int64Value(1)
+	}
+
+	// A todo completed on expiry keeps its configured completed status,
+	// unless it was reopened since, so the next plan completes it again
+	completed := types.BoolValue(*todo[0].Completed)
+	expired := state.Expired
+	if expired.IsNull() {
+		// Imported, so the next plan compares the expiry
+		expired = types.BoolValue(false)
+	}
+	if state.completedByExpiry() {
+		if *todo[0].Completed {
+			completed = state.Completed
+		} else {
+			expired = types.BoolValue(false)
+		}
+	}
+
 	// Overwrite items with refreshed state
 	state = todoResourceModel{
-		ID:          types.Int64Value(todo[0].ID),
-		Description: types.StringValue(*todo[0].Description),
-		Completed:   types.BoolValue(*todo[0].Completed),
+		ID:                  types.Int64Value(todo[0].ID),
+		Description:         newDescriptionValue(description),
+		Completed:           completed,
+		ServerCompleted:     types.BoolValue(*todo[0].Completed),
+		Revision:            revision,
+		PreviousDescription: state.PreviousDescription,
+		ExpiresAt:           state.ExpiresAt,
+		OnExpiry:            state.OnExpiry,
+		Expired:             expired,
 	}
 
 	// Set refreshed state
@@ -196,8 +514,69 @@ func (r *todoResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		return
 	}
 
-	description := plan.Description.ValueString()
-	completed := plan.Completed.ValueBool()
+	// Retrieve prior values from state to track the revision
+	var state todoResourceModel
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	// Decide whether the todo expired if the plan left it to the apply
+	plan.resolveExpired(state.ID, time.Now())
+	planned := plan
+	planRevision(state, &planned)
+
+	// Nothing is left on the server once the todo was deleted on expiry
+	if state.deletedByExpiry() {
+		diags = resp.State.Set(ctx, planned)
+		resp.Diagnostics.Append(diags...)
+		tflog.Debug(ctx, "Updated todo resource", map[string]any{"success": !resp.Diagnostics.HasError()})
+		return
+	}
+	if planned.deletedByExpiry() {
+		params := todos.NewDestroyOneParams()
+		params.SetID(state.ID.ValueInt64())
+		_, err := r.client.Todos.DestroyOne(params)
+		if err != nil && !isTodoNotFound(err) {
+			resp.Diagnostics.AddError(
+				"Error Deleting todo",
+				"Could not delete expired todo, unexpected error: "+err.Error(),
+			)
+			return
+		}
+		tflog.Info(ctx, "Deleted expired todo", map[string]any{"ID": state.ID.String()})
+
+		// Delete the marker publishing the revision log along with the todo
+		revisions, diags := getTodoRevisionLog(ctx, req.Private)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if err := r.writer.deleteRevisionLog(state.ID.ValueInt64(), revisions); err != nil {
+			resp.Diagnostics.AddWarning(
+				"Error deleting todo revisions",
+				"Could not delete the published revision log of todo ID "+state.ID.String()+": "+err.Error(),
+			)
+		}
+
+		// Nothing is left of the todo but the resource
+		planned.ID = types.Int64Null()
+		planned.ServerCompleted = types.BoolNull()
+		diags = resp.State.Set(ctx, planned)
+		resp.Diagnostics.Append(diags...)
+		tflog.Debug(ctx, "Updated todo resource", map[string]any{"success": !resp.Diagnostics.HasError()})
+		return
+	}
+
+	description, err := r.keyring.encrypt(plan.Description.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Updating Todo",
+			"Could not encrypt todo description, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	completed := plan.serverCompleted()
 
 	todo := models.Item{
 		Description: &description,
@@ -209,7 +588,7 @@ func (r *todoResource) Update(ctx context.Context, req resource.UpdateRequest, r
 	params.SetID(plan.ID.ValueInt64())
 
 	// Update existing todo
-	_, err := r.client.Todos.UpdateOne(params)
+	_, err = r.client.Todos.UpdateOne(params)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Updating Todo",
@@ -219,10 +598,8 @@ func (r *todoResource) Update(ctx context.Context, req resource.UpdateRequest, r
 	}
 
 	// Fetch updated items from GetTodo as UpdateTodo items are not
-	// populated.
-	readParams := todos.NewFindTodoParams()
-	readParams.SetID(plan.ID.ValueInt64())
-	result, err := r.client.Todos.FindTodo(readParams)
+	// populated, waiting until the update reads back as written.
+	readTodo, err := waitForTodo(ctx, r.client, plan.ID.ValueInt64(), r.consistencyTimeout, r.matchesPlan(plan))
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading Todo",
@@ -231,13 +608,27 @@ func (r *todoResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		return
 	}
 
-	readTodo := result.GetPayload()
+	readDescription, diags := r.keyring.decryptDescription(readTodo.ID, *readTodo.Description)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// Overwrite items with refreshed state
+	readCompleted := types.BoolValue(*readTodo.Completed)
+	if plan.completedByExpiry() {
+		readCompleted = plan.Completed
+	}
 	plan = todoResourceModel{
-		ID:          types.Int64Value(readTodo[0].ID),
-		Description: types.StringValue(*readTodo[0].Description),
-		Completed:   types.BoolValue(*readTodo[0].Completed),
+		ID:                  types.Int64Value(readTodo.ID),
+		Description:         newDescriptionValue(readDescription),
+		Completed:           readCompleted,
+		ServerCompleted:     types.BoolValue(*readTodo.Completed),
+		Revision:            planned.Revision,
+		PreviousDescription: planned.PreviousDescription,
+		ExpiresAt:           plan.ExpiresAt,
+		OnExpiry:            plan.OnExpiry,
+		Expired:             plan.Expired,
 	}
 
 	// Set refreshed state
@@ -246,9 +637,103 @@ func (r *todoResource) Update(ctx context.Context, req resource.UpdateRequest, r
 	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	// Keep the original creation run but track the new description
+	fingerprint, diags := getTodoFingerprint(ctx, req.Private)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if fingerprint == nil {
+		fingerprint, err = newTodoFingerprint(readDescription, r.descriptionNormalizer)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Updating Todo",
+				"Could not generate todo fingerprint, unexpected error: "+err.Error(),
+			)
+			return
+		}
+	}
+	resp.Diagnostics.Append(setTodoFingerprint(ctx, resp.Private, fingerprint.withDescription(readDescription, r.descriptionNormalizer))...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Record what this update changed in the bounded revision log
+	revisions, diags := getTodoRevisionLog(ctx, req.Private)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if revisions == nil {
+		revisions = &todoRevisionLog{Revision: 1}
+		if !state.Revision.IsNull() {
+			revisions.Revision = state.Revision.ValueInt64()
+		}
+	}
+	revisions.record(state.Description.ValueString(), state.Completed.ValueBool(), planned.Description.ValueString(), planned.Completed.ValueBool())
+	revisions.CreationRun = fingerprint.CreationRun
+
+	// Publish the log for the todo_revisions data source; the update itself
+	// went through, so failing to publish only warns
+	if err := r.writer.publishRevisionLog(ctx, planned.ID.ValueInt64(), revisions); err != nil {
+		resp.Diagnostics.AddWarning(
+			"Error publishing todo revisions",
+			"Could not publish the revision log of todo ID "+strconv.FormatInt(planned.ID.ValueInt64(), 10)+
+				", the todo_revisions data source may return an outdated log: "+err.Error(),
+		)
+	}
+	resp.Diagnostics.Append(setTodoRevisionLog(ctx, resp.Private, revisions)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	tflog.Debug(ctx, "Updated todo resource", map[string]any{"success": true})
 }
 
+// replaced reports whether the todo with ID id, read back with the given
+// description, is an unrelated todo the server handed the ID of the one the
+// fingerprint was taken from to. The creation run published with the
+// revision log decides; a todo without one can only be recognised by its
+// description. A todo without a fingerprint is never considered replaced.
+func (r *todoResource) replaced(ctx context.Context, private privateStateGetter, fingerprint *todoFingerprint, id int64, description string) (bool, diag.Diagnostics) {
+	if fingerprint == nil {
+		return false, nil
+	}
+
+	revisions, diags := getTodoRevisionLog(ctx, private)
+	if diags.HasError() {
+		return false, diags
+	}
+	if revisions == nil || revisions.MarkerID == 0 || revisions.CreationRun != fingerprint.CreationRun {
+		return !fingerprint.matches(description), diags
+	}
+
+	published, err := r.writer.readRevisionMarker(id, revisions.MarkerID)
+	if err != nil {
+		diags.AddError(
+			"Error Reading Todo",
+			"Could not read the revision log of todo ID "+strconv.FormatInt(id, 10)+": "+err.Error(),
+		)
+		return false, diags
+	}
+	return published == nil || published.CreationRun != fingerprint.CreationRun, diags
+}
+
+// matchesPlan returns a waitForTodo matcher for a todo written from plan.
+func (r *todoResource) matchesPlan(plan todoResourceModel) func(*models.Item) (bool, error) {
+	return func(item *models.Item) (bool, error) {
+		if item.Description == nil || item.Completed == nil {
+			return false, nil
+		}
+		description, err := r.keyring.decrypt(*item.Description)
+		if err != nil {
+			return false, err
+		}
+		return r.descriptionNormalizer.equal(plan.Description.ValueString(), description) &&
+			*item.Completed == plan.serverCompleted(), nil
+	}
+}
+
 func (r *todoResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 	tflog.Debug(ctx, "Preparing to delete todo resource")
 	// Retrieve values from state
@@ -259,6 +744,25 @@ func (r *todoResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 		return
 	}
 
+	// Nothing is left on the server once the todo was deleted on expiry
+	if state.deletedByExpiry() {
+		tflog.Debug(ctx, "Deleted todo resource", map[string]any{"success": true})
+		return
+	}
+
+	// Delete the marker publishing the revision log along with the todo
+	revisions, diags := getTodoRevisionLog(ctx, req.Private)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if err := r.writer.deleteRevisionLog(state.ID.ValueInt64(), revisions); err != nil {
+		resp.Diagnostics.AddWarning(
+			"Error deleting todo revisions",
+			"Could not delete the published revision log of todo ID "+strconv.FormatInt(state.ID.ValueInt64(), 10)+": "+err.Error(),
+		)
+	}
+
 	// Delete existing todo
 	params := todos.NewDestroyOneParams()
 	params.SetID(state.ID.ValueInt64())
@@ -1,8 +1,10 @@
 package todo
 
 import (
+	"context"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 )
 
@@ -21,6 +23,8 @@ resource "todo_todo" "test" {
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttr("todo_todo.test", "description", "Go Shopping"),
 					resource.TestCheckResourceAttr("todo_todo.test", "completed", "false"),
+					resource.TestCheckResourceAttr("todo_todo.test", "revision", "1"),
+					resource.TestCheckNoResourceAttr("todo_todo.test", "previous_description"),
 					// Verify dynamic values have any value set in the state.
 					resource.TestCheckResourceAttrSet("todo_todo.test", "id"),
 				),
@@ -42,6 +46,8 @@ resource "todo_todo" "test" {
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttr("todo_todo.test", "description", "Go shopping for avocados"),
 					resource.TestCheckResourceAttr("todo_todo.test", "completed", "true"),
+					resource.TestCheckResourceAttr("todo_todo.test", "revision", "2"),
+					resource.TestCheckResourceAttr("todo_todo.test", "previous_description", "Go Shopping"),
 					// Verify dynamic values have any value set in the state.
 					resource.TestCheckResourceAttrSet("todo_todo.test", "id"),
 				),
@@ -50,3 +56,123 @@ resource "todo_todo" "test" {
 		},
 	})
 }
+
+func TestAccTodoResourceExpiry(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: providerConfig + `
+resource "todo_todo" "test" {
+	description = "Review the CI run"
+	completed   = false
+	expires_at  = "2020-01-01T00:00:00Z"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("todo_todo.test", "expired", "true"),
+					resource.TestCheckResourceAttr("todo_todo.test", "completed", "false"),
+					resource.TestCheckResourceAttr("todo_todo.test", "server_completed", "true"),
+					resource.TestCheckResourceAttrSet("todo_todo.test", "id"),
+				),
+			},
+			// Update and Read testing
+			{
+				Config: providerConfig + `
+resource "todo_todo" "test" {
+	description = "Review the CI run"
+	completed   = false
+	expires_at  = "2020-01-01T00:00:00Z"
+	on_expiry   = "delete"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("todo_todo.test", "expired", "true"),
+					resource.TestCheckNoResourceAttr("todo_todo.test", "id"),
+					resource.TestCheckNoResourceAttr("todo_todo.test", "server_completed"),
+				),
+			},
+			// A deleted todo comes back once its expiry is moved
+			{
+				Config: providerConfig + `
+resource "todo_todo" "test" {
+	description = "Review the CI run"
+	completed   = false
+	expires_at  = "2999-01-01T00:00:00Z"
+	on_expiry   = "delete"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("todo_todo.test", "expired", "false"),
+					resource.TestCheckResourceAttrSet("todo_todo.test", "id"),
+					resource.TestCheckResourceAttr("todo_todo.test", "server_completed", "false"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+// testPrivateState is an in-memory private state.
+type testPrivateState map[string][]byte
+
+func (p testPrivateState) GetKey(_ context.Context, key string) ([]byte, diag.Diagnostics) {
+	return p[key], nil
+}
+
+func (p testPrivateState) SetKey(_ context.Context, key string, value []byte) diag.Diagnostics {
+	p[key] = value
+	return nil
+}
+
+func TestTodoResourceReplaced(t *testing.T) {
+	ctx := context.Background()
+	server, c := newFakeTodoServer(t, map[int64]map[string]any{
+		1: {"id": 1, "description": "Go Shopping", "completed": false},
+	})
+	r := &todoResource{client: c, writer: &todoWriter{client: c}}
+
+	fingerprint, err := newTodoFingerprint("Go Shopping", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	private := testPrivateState{}
+
+	// Without a published creation run only the description tells
+	if replaced, diags := r.replaced(ctx, private, fingerprint, 1, "Go shopping for avocados"); diags.HasError() || !replaced {
+		t.Errorf("expected an edited todo without a marker to look replaced, got %v (%v)", replaced, diags)
+	}
+
+	revisions := &todoRevisionLog{Revision: 1, CreationRun: fingerprint.CreationRun}
+	if err := r.writer.publishRevisionLog(ctx, 1, revisions); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	setTodoRevisionLog(ctx, private, revisions)
+
+	// An edit outside Terraform is drift, not ID reuse
+	if replaced, diags := r.replaced(ctx, private, fingerprint, 1, "Go shopping for avocados"); diags.HasError() || replaced {
+		t.Errorf("expected an edited todo to be kept, got %v (%v)", replaced, diags)
+	}
+
+	// After the server handed both IDs to another create, the marker names
+	// its creation run
+	marker, err := revisionMarker(nil, 1, &todoRevisionLog{Revision: 1, CreationRun: "another-run"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	server.todos[revisions.MarkerID] = map[string]any{"id": revisions.MarkerID, "description": marker, "completed": true}
+	if replaced, diags := r.replaced(ctx, private, fingerprint, 1, "Go Shopping"); diags.HasError() || !replaced {
+		t.Errorf("expected a todo from another creation run to be replaced, got %v (%v)", replaced, diags)
+	}
+
+	// The server losing the marker means it lost the todo as well
+	delete(server.todos, revisions.MarkerID)
+	if replaced, diags := r.replaced(ctx, private, fingerprint, 1, "Go Shopping"); diags.HasError() || !replaced {
+		t.Errorf("expected a todo without its marker to be replaced, got %v (%v)", replaced, diags)
+	}
+
+	if replaced, diags := r.replaced(ctx, private, nil, 1, "Walk the dog"); diags.HasError() || replaced {
+		t.Errorf("expected a todo without a fingerprint to be adopted, got %v (%v)", replaced, diags)
+	}
+}
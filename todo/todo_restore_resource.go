@@ -0,0 +1,358 @@
+package todo
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	// Todo API Libraries
+	"github.com/spkane/todo-for-terraform/models"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource              = &todoRestoreResource{}
+	_ resource.ResourceWithConfigure = &todoRestoreResource{}
+)
+
+// NewTodoRestoreResource is a helper function to simplify the provider implementation.
+func NewTodoRestoreResource() resource.Resource {
+	return &todoRestoreResource{}
+}
+
+// todoRestoreResource is the resource implementation.
+type todoRestoreResource struct {
+	writer *todoWriter
+}
+
+// todoRestoreResourceModel maps the resource schema data.
+type todoRestoreResourceModel struct {
+	ID               types.String `tfsdk:"id"`
+	Path             types.String `tfsdk:"path"`
+	Prune            types.Bool   `tfsdk:"prune"`
+	Triggers         types.Map    `tfsdk:"triggers"`
+	SnapshotChecksum types.String `tfsdk:"snapshot_checksum"`
+	IDMap            types.Map    `tfsdk:"id_map"`
+	CreatedCount     types.Int64  `tfsdk:"created_count"`
+	UpdatedCount     types.Int64  `tfsdk:"updated_count"`
+	DeletedCount     types.Int64  `tfsdk:"deleted_count"`
+}
+
+// todoRestoreResult is the outcome of restoring a snapshot.
+type todoRestoreResult struct {
+	// idMap maps the ID of each snapshot todo to the ID it has on the
+	// server, which differs for todos that were recreated.
+	idMap   map[int64]int64
+	created int
+	updated int
+	deleted int
+	errs    []error
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *todoRestoreResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	r.writer = newTodoWriter(req.ProviderData.(*todoProviderData))
+}
+
+// Metadata returns the resource type name.
+func (r *todoRestoreResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_restore"
+}
+
+// Schema defines the schema for the resource.
+func (r *todoRestoreResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Restore the Todo server to a snapshot written by todo_snapshot. Todos that still exist are updated to " +
+			"match the snapshot, missing todos are recreated under new IDs, and todos that are not in the snapshot are " +
+			"deleted. The restore runs when the resource is created and again whenever its arguments change. Destroying " +
+			"the resource changes nothing.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The time the restore last ran, in RFC 3339 format.",
+				Computed:    true,
+			},
+			"path": schema.StringAttribute{
+				Description: "The path of the snapshot file.",
+				Required:    true,
+			},
+			"prune": schema.BoolAttribute{
+				Description: "Delete todos that are not in the snapshot (default: true).",
+				Optional:    true,
+			},
+			"triggers": schema.MapAttribute{
+				Description: "Arbitrary values that run the restore again when they change.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"snapshot_checksum": schema.StringAttribute{
+				Description: "The checksum of the restored snapshot.",
+				Computed:    true,
+			},
+			"id_map": schema.MapAttribute{
+				Description: "The ID each snapshot todo has on the server, keyed by its ID in the snapshot. The IDs differ for " +
+					"todos that were recreated, and a later restore uses the map to update them instead of recreating them again.",
+				Computed:    true,
+				ElementType: types.Int64Type,
+			},
+			"created_count": schema.Int64Attribute{
+				Description: "The number of todos the last restore recreated.",
+				Computed:    true,
+			},
+			"updated_count": schema.Int64Attribute{
+				Description: "The number of todos the last restore updated.",
+				Computed:    true,
+			},
+			"deleted_count": schema.Int64Attribute{
+				Description: "The number of todos the last restore deleted.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// Create restores the snapshot.
+func (r *todoRestoreResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	tflog.Debug(ctx, "Preparing to create todo restore resource")
+	// Retrieve values from plan
+	var plan todoRestoreResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.restore(ctx, &plan, nil)...)
+	if plan.ID.IsUnknown() {
+		return
+	}
+
+	// Set state to what was restored, even if some todos failed
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	tflog.Debug(ctx, "Created todo restore resource", map[string]any{"success": !resp.Diagnostics.HasError()})
+}
+
+// Read keeps the result of the restore, which is not stored on the server.
+func (r *todoRestoreResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state todoRestoreResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update restores the snapshot again, matching todos recreated by the
+// previous restore through its ID map.
+func (r *todoRestoreResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	tflog.Debug(ctx, "Preparing to update todo restore resource")
+	// Retrieve values from plan and state
+	var plan, state todoRestoreResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	previous, diags := parseIDMap(ctx, state.IDMap)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.restore(ctx, &plan, previous)...)
+	if plan.ID.IsUnknown() {
+		return
+	}
+
+	// Set state to what was restored, even if some todos failed
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	tflog.Debug(ctx, "Updated todo restore resource", map[string]any{"success": !resp.Diagnostics.HasError()})
+}
+
+// Delete removes the restore from state without touching the server.
+func (r *todoRestoreResource) Delete(ctx context.Context, _ resource.DeleteRequest, _ *resource.DeleteResponse) {
+	tflog.Debug(ctx, "Deleted todo restore resource", map[string]any{"success": true})
+}
+
+// restore reads the snapshot of plan and restores it, filling in the
+// computed attributes of plan. The ID of plan is left unknown if nothing
+// was restored.
+func (r *todoRestoreResource) restore(ctx context.Context, plan *todoRestoreResourceModel, previous map[int64]int64) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	snapshot, err := readTodoSnapshot(plan.Path.ValueString())
+	if err != nil {
+		diags.AddError(
+			"Error Reading Snapshot",
+			"Could not read snapshot from "+plan.Path.ValueString()+": "+err.Error(),
+		)
+		return diags
+	}
+
+	prune := plan.Prune.IsNull() || plan.Prune.ValueBool()
+	result := r.writer.restoreSnapshot(ctx, snapshot, previous, prune)
+	tflog.Info(ctx, "Restored todo snapshot", map[string]any{
+		"path":    plan.Path.ValueString(),
+		"created": result.created,
+		"updated": result.updated,
+		"deleted": result.deleted,
+		"errors":  len(result.errs)})
+
+	idMap := make(map[string]int64, len(result.idMap))
+	for snapshotID, id := range result.idMap {
+		idMap[strconv.FormatInt(snapshotID, 10)] = id
+	}
+	var mapDiags diag.Diagnostics
+	plan.IDMap, mapDiags = types.MapValueFrom(ctx, types.Int64Type, idMap)
+	diags.Append(mapDiags...)
+	if diags.HasError() {
+		return diags
+	}
+	plan.ID = types.StringValue(time.Now().UTC().Format(time.RFC3339))
+	plan.SnapshotChecksum = types.StringValue(snapshot.Checksum)
+	plan.CreatedCount = types.Int64Value(int64(result.created))
+	plan.UpdatedCount = types.Int64Value(int64(result.updated))
+	plan.DeletedCount = types.Int64Value(int64(result.deleted))
+
+	if len(result.errs) > 0 {
+		messages := make([]string, 0, len(result.errs))
+		for _, err := range result.errs {
+			messages = append(messages, err.Error())
+		}
+		diags.AddError(
+			"Error Restoring Snapshot",
+			"Could not restore some todos, run the restore again to retry them:\n\n"+strings.Join(messages, "\n"),
+		)
+	}
+	return diags
+}
+
+// restoreSnapshot makes the todos on the server match a snapshot. Snapshot
+// todos are matched to todos on the server by ID, or else by the ID a
+// previous restore recreated them under. Matched todos that differ are
+// updated, unmatched ones are recreated in snapshot order and, if prune is
+// set, todos that match nothing are deleted first.
+//
+// Failures are collected without stopping the restore.
+func (w *todoWriter) restoreSnapshot(ctx context.Context, snapshot *todoSnapshot, previous map[int64]int64, prune bool) todoRestoreResult {
+	result := todoRestoreResult{idMap: make(map[int64]int64, len(snapshot.Todos))}
+
+	existing := make(map[int64]*models.Item)
+	err := forEachTodo(ctx, w.client, 0, func(item *models.Item) bool {
+		if item.Description != nil && item.Completed != nil {
+			existing[item.ID] = item
+		}
+		return true
+	})
+	if err != nil {
+		result.errs = append(result.errs, err)
+		return result
+	}
+
+	// Match by ID before using the previous restore's IDs, so a todo that
+	// kept its ID is never claimed for another one
+	claimed := make(map[int64]bool, len(existing))
+	for _, item := range snapshot.Todos {
+		if existing[item.ID] != nil {
+			result.idMap[item.ID] = item.ID
+			claimed[item.ID] = true
+		}
+	}
+	for _, item := range snapshot.Todos {
+		if _, ok := result.idMap[item.ID]; ok {
+			continue
+		}
+		if id, ok := previous[item.ID]; ok && existing[id] != nil && !claimed[id] {
+			result.idMap[item.ID] = id
+			claimed[id] = true
+		}
+	}
+
+	if prune {
+		extra := make([]int64, 0, len(existing))
+		for id := range existing {
+			if !claimed[id] {
+				extra = append(extra, id)
+			}
+		}
+		sort.Slice(extra, func(i, j int) bool { return extra[i] < extra[j] })
+		for _, id := range extra {
+			if err := w.delete(id); err != nil {
+				result.errs = append(result.errs, fmt.Errorf("could not delete todo ID %d: %w", id, err))
+				continue
+			}
+			result.deleted++
+		}
+	}
+
+	for _, item := range snapshot.Todos {
+		id, ok := result.idMap[item.ID]
+		if !ok {
+			created, err := w.createStored(ctx, item.Description, item.Completed)
+			if created != 0 {
+				result.idMap[item.ID] = created
+			}
+			if err != nil {
+				result.errs = append(result.errs, fmt.Errorf("could not recreate snapshot todo ID %d: %w", item.ID, err))
+				continue
+			}
+			tflog.Debug(ctx, "Recreated snapshot todo", map[string]any{"snapshot_id": item.ID, "ID": created})
+			result.created++
+			continue
+		}
+
+		current := existing[id]
+		if *current.Description == item.Description && *current.Completed == item.Completed {
+			continue
+		}
+		if err := w.writeStored(ctx, id, item.Description, item.Completed); err != nil {
+			result.errs = append(result.errs, fmt.Errorf("could not update todo ID %d: %w", id, err))
+			continue
+		}
+		result.updated++
+	}
+	return result
+}
+
+// parseIDMap converts an id_map attribute back to the IDs it maps between.
+func parseIDMap(ctx context.Context, value types.Map) (map[int64]int64, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	var idMap map[string]int64
+	diags.Append(value.ElementsAs(ctx, &idMap, false)...)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	converted := make(map[int64]int64, len(idMap))
+	for key, id := range idMap {
+		snapshotID, err := strconv.ParseInt(key, 10, 64)
+		if err != nil {
+			diags.AddError(
+				"Invalid ID Map",
+				"The id_map in state has a key that is not a todo ID: "+key,
+			)
+			continue
+		}
+		converted[snapshotID] = id
+	}
+	return converted, diags
+}
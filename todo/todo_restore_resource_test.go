@@ -0,0 +1,149 @@
+package todo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccTodoRestoreResource(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "todos.json")
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: providerConfig + fmt.Sprintf(`
+resource "todo_snapshot" "test" {
+	path = %q
+}
+
+resource "todo_restore" "test" {
+	path  = todo_snapshot.test.path
+	prune = false
+
+	triggers = {
+		checksum = todo_snapshot.test.checksum
+	}
+}
+`, path),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("todo_restore.test", "snapshot_checksum", "todo_snapshot.test", "checksum"),
+					resource.TestCheckResourceAttrPair("todo_restore.test", "id_map.%", "todo_snapshot.test", "todo_count"),
+					resource.TestCheckResourceAttr("todo_restore.test", "created_count", "0"),
+					resource.TestCheckResourceAttr("todo_restore.test", "deleted_count", "0"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestTodoWriterRestoreSnapshot(t *testing.T) {
+	var mu sync.Mutex
+	server := map[int64]map[string]any{
+		1: {"id": 1, "description": "Go Shopping", "completed": true},
+		5: {"id": 5, "description": "Recreated earlier", "completed": false},
+		6: {"id": 6, "description": "Not in the snapshot", "completed": false},
+	}
+	nextID := int64(7)
+	w := &todoWriter{client: newTestTodoClient(t, func(rw http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		var id int64
+		fmt.Sscanf(r.URL.Path, "/%d", &id)
+		var body map[string]any
+		if r.Body != nil {
+			encoded, _ := io.ReadAll(r.Body)
+			_ = json.Unmarshal(encoded, &body)
+		}
+
+		rw.Header().Set("Content-Type", "application/spkane.todo-list.v1+json")
+		switch {
+		case r.Method == http.MethodGet && id != 0:
+			items := []map[string]any{}
+			if item, ok := server[id]; ok {
+				items = append(items, item)
+			}
+			_ = json.NewEncoder(rw).Encode(items)
+		case r.Method == http.MethodGet:
+			since, _ := strconv.ParseInt(r.URL.Query().Get("since"), 10, 64)
+			items := []map[string]any{}
+			for itemID, item := range server {
+				if itemID > since {
+					items = append(items, item)
+				}
+			}
+			_ = json.NewEncoder(rw).Encode(items)
+		case r.Method == http.MethodPost:
+			body["id"] = nextID
+			server[nextID] = body
+			nextID++
+			rw.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(rw).Encode(body)
+		case r.Method == http.MethodPut:
+			body["id"] = id
+			server[id] = body
+			_ = json.NewEncoder(rw).Encode(body)
+		case r.Method == http.MethodDelete:
+			delete(server, id)
+			rw.WriteHeader(http.StatusNoContent)
+		}
+	})}
+
+	snapshot := &todoSnapshot{Todos: []todoSnapshotItem{
+		{ID: 1, Description: "Go Shopping", Completed: false},
+		{ID: 2, Description: "Recreated earlier", Completed: false},
+		{ID: 3, Description: "Walk the dog", Completed: true},
+	}}
+	result := w.restoreSnapshot(context.Background(), snapshot, map[int64]int64{2: 5}, true)
+	if len(result.errs) > 0 {
+		t.Fatalf("unexpected errors: %v", result.errs)
+	}
+	if result.created != 1 || result.updated != 1 || result.deleted != 1 {
+		t.Errorf("expected 1 created, updated and deleted, got %+v", result)
+	}
+	if result.idMap[1] != 1 || result.idMap[2] != 5 || result.idMap[3] < 7 {
+		t.Errorf("unexpected ID map %v", result.idMap)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(server) != 3 || server[6] != nil || server[1]["completed"] != false {
+		t.Errorf("unexpected todos on the server %v", server)
+	}
+	recreated := server[result.idMap[3]]
+	if recreated == nil || recreated["description"] != "Walk the dog" || recreated["completed"] != true {
+		t.Errorf("expected the missing todo to be recreated, got %v", recreated)
+	}
+}
+
+func TestParseIDMap(t *testing.T) {
+	value := types.MapValueMust(types.Int64Type, map[string]attr.Value{
+		"2": types.Int64Value(5),
+	})
+	idMap, diags := parseIDMap(context.Background(), value)
+	if diags.HasError() || len(idMap) != 1 || idMap[2] != 5 {
+		t.Errorf("unexpected ID map %v (%v)", idMap, diags)
+	}
+
+	invalid := types.MapValueMust(types.Int64Type, map[string]attr.Value{
+		"two": types.Int64Value(5),
+	})
+	if _, diags := parseIDMap(context.Background(), invalid); !diags.HasError() || !strings.Contains(diags[0].Detail(), "two") {
+		t.Errorf("expected an invalid key error, got %v", diags)
+	}
+}
@@ -0,0 +1,238 @@
+package todo
+
+import (
+	"context"
+	"encoding/json"
+	"regexp"
+	"strconv"
+	"time"
+
+	// Todo API Libraries
+	"github.com/spkane/todo-for-terraform/client"
+	"github.com/spkane/todo-for-terraform/models"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+const (
+	// revisionsPrivateKey is the private state key holding a todoRevisionLog.
+	revisionsPrivateKey = "revisions"
+
+	// revisionLogLimit is the number of revisions kept in private state.
+	revisionLogLimit = 20
+)
+
+// revisionMarkerPattern matches the description of a marker todo that
+// publishes the revision log of a todo: the todo's ID followed by the log as
+// JSON, encrypted when the provider encrypts descriptions. Markers are
+// created completed and are left out of todo lists.
+var revisionMarkerPattern = regexp.MustCompile(`^\[todo-revisions:(\d+)\] (.+)$`)
+
+// todoRevision records what a single Terraform update changed. Only the
+// fields that changed are set.
+type todoRevision struct {
+	Revision            int64   `json:"revision"`
+	ChangedAt           string  `json:"changed_at"`
+	PreviousDescription *string `json:"previous_description,omitempty"`
+	PreviousCompleted   *bool   `json:"previous_completed,omitempty"`
+}
+
+// todoRevisionLog is the bounded history of a todo's revisions, oldest first.
+type todoRevisionLog struct {
+	Revision  int64          `json:"revision"`
+	Revisions []todoRevision `json:"revisions"`
+	// CreationRun is the creation run of the todo's fingerprint. Read
+	// compares the published one with it to detect ID reuse.
+	CreationRun string `json:"creation_run,omitempty"`
+	// MarkerID is the ID of the marker todo the log is published to.
+	MarkerID int64 `json:"marker_id,omitempty"`
+}
+
+// record appends a revision for a change from the prior description and
+// completed status, dropping the oldest entries past revisionLogLimit.
+func (l *todoRevisionLog) record(priorDescription string, priorCompleted bool, description string, completed bool) {
+	if priorDescription == description && priorCompleted == completed {
+		return
+	}
+
+	l.Revision++
+	revision := todoRevision{
+		Revision:  l.Revision,
+		ChangedAt: time.Now().UTC().Format(time.RFC3339),
+	}
+	if priorDescription != description {
+		revision.PreviousDescription = &priorDescription
+	}
+	if priorCompleted != completed {
+		revision.PreviousCompleted = &priorCompleted
+	}
+
+	l.Revisions = append(l.Revisions, revision)
+	if len(l.Revisions) > revisionLogLimit {
+		l.Revisions = l.Revisions[len(l.Revisions)-revisionLogLimit:]
+	}
+}
+
+// getTodoRevisionLog loads the revision log from private state. A nil log is
+// returned for imported todos and state written by older provider versions.
+func getTodoRevisionLog(ctx context.Context, private privateStateGetter) (*todoRevisionLog, diag.Diagnostics) {
+	data, diags := private.GetKey(ctx, revisionsPrivateKey)
+	if diags.HasError() || len(data) == 0 {
+		return nil, diags
+	}
+
+	var log todoRevisionLog
+	if err := json.Unmarshal(data, &log); err != nil {
+		diags.AddError(
+			"Error Reading Todo Revisions",
+			"Could not decode the todo revision log stored in private state: "+err.Error(),
+		)
+		return nil, diags
+	}
+	return &log, diags
+}
+
+// setTodoRevisionLog stores the revision log in private state.
+func setTodoRevisionLog(ctx context.Context, private privateStateSetter, log *todoRevisionLog) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	data, err := json.Marshal(log)
+	if err != nil {
+		diags.AddError(
+			"Error Saving Todo Revisions",
+			"Could not encode the todo revision log for private state: "+err.Error(),
+		)
+		return diags
+	}
+	return private.SetKey(ctx, revisionsPrivateKey, data)
+}
+
+// revisionMarker returns the description of the marker todo publishing the
+// revision log of todo id.
+func revisionMarker(keyring *todoKeyring, id int64, log *todoRevisionLog) (string, error) {
+	published := *log
+	published.MarkerID = 0
+	data, err := json.Marshal(published)
+	if err != nil {
+		return "", err
+	}
+	payload, err := keyring.encrypt(string(data))
+	if err != nil {
+		return "", err
+	}
+	return "[todo-revisions:" + strconv.FormatInt(id, 10) + "] " + payload, nil
+}
+
+// parseRevisionMarker returns the ID of the todo whose revision log a marker
+// todo description publishes and the log as stored, and false if it is not
+// a marker.
+func parseRevisionMarker(description string) (int64, string, bool) {
+	match := revisionMarkerPattern.FindStringSubmatch(description)
+	if match == nil {
+		return 0, "", false
+	}
+	id, err := strconv.ParseInt(match[1], 10, 64)
+	if err != nil {
+		return 0, "", false
+	}
+	return id, match[2], true
+}
+
+// decodeRevisionMarker decodes the log a revision marker stores.
+func decodeRevisionMarker(keyring *todoKeyring, markerID int64, payload string) (*todoRevisionLog, error) {
+	data, err := keyring.decrypt(payload)
+	if err != nil {
+		return nil, err
+	}
+	var log todoRevisionLog
+	if err := json.Unmarshal([]byte(data), &log); err != nil {
+		return nil, err
+	}
+	log.MarkerID = markerID
+	return &log, nil
+}
+
+// isRevisionMarkerOf reports whether markerID is the revision marker todo
+// of todo id. The server may have handed the ID to another todo since.
+func (w *todoWriter) isRevisionMarkerOf(id, markerID int64) (bool, error) {
+	item, err := w.readStored(markerID)
+	if item == nil || err != nil {
+		return false, err
+	}
+	markerOf, _, ok := parseRevisionMarker(*item.Description)
+	return ok && markerOf == id, nil
+}
+
+// readRevisionMarker reads the revision log published by marker todo
+// markerID, or nil if that is no longer the revision marker of todo id.
+func (w *todoWriter) readRevisionMarker(id, markerID int64) (*todoRevisionLog, error) {
+	item, err := w.readStored(markerID)
+	if item == nil || err != nil {
+		return nil, err
+	}
+	markerOf, payload, ok := parseRevisionMarker(*item.Description)
+	if !ok || markerOf != id {
+		return nil, nil
+	}
+	return decodeRevisionMarker(w.keyring, markerID, payload)
+}
+
+// publishRevisionLog writes the revision log of todo id to its marker todo,
+// creating the marker if the log has none yet or it is gone, and records the
+// marker's ID in the log.
+func (w *todoWriter) publishRevisionLog(ctx context.Context, id int64, log *todoRevisionLog) error {
+	description, err := revisionMarker(w.keyring, id, log)
+	if err != nil {
+		return err
+	}
+
+	if log.MarkerID != 0 {
+		ours, err := w.isRevisionMarkerOf(id, log.MarkerID)
+		if err != nil {
+			return err
+		}
+		if ours {
+			return w.writeStored(ctx, log.MarkerID, description, true)
+		}
+	}
+
+	markerID, err := w.createStored(ctx, description, true)
+	if markerID != 0 {
+		log.MarkerID = markerID
+	}
+	return err
+}
+
+// deleteRevisionLog deletes the marker todo publishing the revision log of
+// todo id, if it still has one.
+func (w *todoWriter) deleteRevisionLog(id int64, log *todoRevisionLog) error {
+	if log == nil || log.MarkerID == 0 {
+		return nil
+	}
+	ours, err := w.isRevisionMarkerOf(id, log.MarkerID)
+	if !ours || err != nil {
+		return err
+	}
+	return w.delete(log.MarkerID)
+}
+
+// findRevisionLog reads the revision log published for todo id, or nil if
+// there is none. If there are several markers, the newest one wins.
+func findRevisionLog(ctx context.Context, c *client.TodoList, keyring *todoKeyring, id int64) (*todoRevisionLog, error) {
+	var marker *models.Item
+	var payload string
+	err := forEachTodo(ctx, c, 0, func(item *models.Item) bool {
+		if item.Description == nil {
+			return true
+		}
+		markerOf, stored, ok := parseRevisionMarker(*item.Description)
+		if ok && markerOf == id && (marker == nil || item.ID > marker.ID) {
+			marker, payload = item, stored
+		}
+		return true
+	})
+	if marker == nil || err != nil {
+		return nil, err
+	}
+	return decodeRevisionMarker(keyring, marker.ID, payload)
+}
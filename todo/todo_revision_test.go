@@ -0,0 +1,121 @@
+package todo
+
+import (
+	"context"
+	"strconv"
+	"testing"
+)
+
+func TestTodoRevisionLogRecord(t *testing.T) {
+	log := &todoRevisionLog{Revision: 1}
+
+	log.record("Go Shopping", false, "Go Shopping", false)
+	if log.Revision != 1 || len(log.Revisions) != 0 {
+		t.Fatalf("expected no revision without a change, got %+v", log)
+	}
+
+	log.record("Go Shopping", false, "Go shopping for avocados", false)
+	log.record("Go shopping for avocados", false, "Go shopping for avocados", true)
+	if log.Revision != 3 || len(log.Revisions) != 2 {
+		t.Fatalf("expected two revisions, got %+v", log)
+	}
+
+	first, second := log.Revisions[0], log.Revisions[1]
+	if first.Revision != 2 || first.PreviousDescription == nil || *first.PreviousDescription != "Go Shopping" || first.PreviousCompleted != nil {
+		t.Errorf("unexpected description revision: %+v", first)
+	}
+	if second.Revision != 3 || second.PreviousDescription != nil || second.PreviousCompleted == nil || *second.PreviousCompleted {
+		t.Errorf("unexpected completion revision: %+v", second)
+	}
+}
+
+func TestTodoRevisionLogLimit(t *testing.T) {
+	log := &todoRevisionLog{Revision: 1}
+	for i := 0; i < revisionLogLimit+5; i++ {
+		log.record(strconv.Itoa(i), false, strconv.Itoa(i+1), false)
+	}
+
+	if len(log.Revisions) != revisionLogLimit {
+		t.Fatalf("expected %d revisions, got %d", revisionLogLimit, len(log.Revisions))
+	}
+	if oldest := log.Revisions[0]; oldest.Revision != 7 {
+		t.Errorf("expected the oldest kept revision to be 7, got %d", oldest.Revision)
+	}
+	if log.Revision != int64(revisionLogLimit+6) {
+		t.Errorf("expected revision %d, got %d", revisionLogLimit+6, log.Revision)
+	}
+}
+
+func TestTodoRevisionLogPublish(t *testing.T) {
+	server, c := newFakeTodoServer(t, map[int64]map[string]any{
+		1: {"id": 1, "description": "Go shopping for avocados", "completed": false},
+		2: {"id": 2, "description": "Walk the dog", "completed": false},
+	})
+	w := &todoWriter{client: c}
+
+	log := &todoRevisionLog{Revision: 1}
+	log.record("Go Shopping", false, "Go shopping for avocados", false)
+	if err := w.publishRevisionLog(context.Background(), 1, log); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if log.MarkerID != 3 || server.get(3)["completed"] != true {
+		t.Fatalf("expected a completed marker todo 3, got %d and %v", log.MarkerID, server.get(3))
+	}
+
+	// Publishing again rewrites the same marker
+	log.record("Go shopping for avocados", false, "Go shopping for avocados", true)
+	if err := w.publishRevisionLog(context.Background(), 1, log); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if log.MarkerID != 3 || server.get(4) != nil {
+		t.Fatalf("expected marker todo 3 to be rewritten, got %d", log.MarkerID)
+	}
+
+	found, err := findRevisionLog(context.Background(), c, nil, 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if found == nil || found.Revision != 3 || len(found.Revisions) != 2 || found.MarkerID != 3 {
+		t.Errorf("expected the published log, got %+v", found)
+	}
+	if other, err := findRevisionLog(context.Background(), c, nil, 2); other != nil || err != nil {
+		t.Errorf("expected no log for a todo Terraform has not changed, got %+v (%v)", other, err)
+	}
+
+	// A marker ID the server handed to another todo is neither rewritten
+	// nor deleted
+	server.todos[3] = map[string]any{"id": 3, "description": "Feed the cat", "completed": false}
+	if err := w.deleteRevisionLog(1, log); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if server.get(3) == nil {
+		t.Fatal("expected the todo reusing the marker ID to be kept")
+	}
+	if err := w.publishRevisionLog(context.Background(), 1, log); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if log.MarkerID != 4 || server.get(3)["description"] != "Feed the cat" {
+		t.Fatalf("expected a new marker todo 4, got %d", log.MarkerID)
+	}
+
+	if err := w.deleteRevisionLog(1, log); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if server.get(4) != nil {
+		t.Error("expected the marker todo to be deleted")
+	}
+}
+
+func TestParseRevisionMarker(t *testing.T) {
+	description, err := revisionMarker(nil, 12, &todoRevisionLog{Revision: 2, MarkerID: 13})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	id, payload, ok := parseRevisionMarker(description)
+	if !ok || id != 12 || payload != `{"revision":2,"revisions":null}` {
+		t.Errorf("unexpected marker %q: %d %q %v", description, id, payload, ok)
+	}
+	if _, _, ok := parseRevisionMarker("Go Shopping"); ok {
+		t.Error("expected a plain description not to be a marker")
+	}
+}
@@ -0,0 +1,165 @@
+package todo
+
+import (
+	"context"
+	"strconv"
+
+	// Todo API Libraries
+	"github.com/spkane/todo-for-terraform/client"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &todoRevisionsDataSource{}
+	_ datasource.DataSourceWithConfigure = &todoRevisionsDataSource{}
+)
+
+// NewTodoRevisionsDataSource is a helper function to simplify the provider implementation.
+func NewTodoRevisionsDataSource() datasource.DataSource {
+	return &todoRevisionsDataSource{}
+}
+
+// todoRevisionsDataSource is the data source implementation.
+type todoRevisionsDataSource struct {
+	client  *client.TodoList
+	keyring *todoKeyring
+	writer  *todoWriter
+}
+
+// todoRevisionsDataSourceModel maps the data source schema data.
+type todoRevisionsDataSourceModel struct {
+	ID        types.Int64         `tfsdk:"id"`
+	Revision  types.Int64         `tfsdk:"revision"`
+	Revisions []todoRevisionModel `tfsdk:"revisions"`
+}
+
+// todoRevisionModel maps a single revision.
+type todoRevisionModel struct {
+	Revision            types.Int64  `tfsdk:"revision"`
+	ChangedAt           types.String `tfsdk:"changed_at"`
+	PreviousDescription types.String `tfsdk:"previous_description"`
+	PreviousCompleted   types.Bool   `tfsdk:"previous_completed"`
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *todoRevisionsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData := req.ProviderData.(*todoProviderData)
+	d.client = providerData.client
+	d.keyring = providerData.keyring
+	d.writer = newTodoWriter(providerData)
+}
+
+// Metadata returns the data source type name.
+func (d *todoRevisionsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_revisions"
+}
+
+// Schema defines the schema for the data source.
+func (d *todoRevisionsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Fetch the revision history of a todo managed by a `todo_todo` resource. " +
+			"When Terraform creates or changes the todo, the resource publishes its last " + strconv.Itoa(revisionLogLimit) + " revisions to the Todo server " +
+			"in a completed marker todo, which other todo lists leave out. Changes made outside Terraform are not recorded.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				Description: "The unique identifier for the todo.",
+				Required:    true,
+			},
+			"revision": schema.Int64Attribute{
+				Description: "The current revision of the todo, starting at 1 and increased every time Terraform changes its description or completed status.",
+				Computed:    true,
+			},
+			"revisions": schema.ListNestedAttribute{
+				Description: "The changes Terraform made to the todo, oldest first. Empty if Terraform has not changed the todo since creating it.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"revision": schema.Int64Attribute{
+							Description: "The revision the change created.",
+							Computed:    true,
+						},
+						"changed_at": schema.StringAttribute{
+							Description: "When the change was applied, in RFC 3339 format.",
+							Computed:    true,
+						},
+						"previous_description": schema.StringAttribute{
+							Description: "The description before the change, if the change edited it.",
+							Computed:    true,
+						},
+						"previous_completed": schema.BoolAttribute{
+							Description: "The completed status before the change, if the change flipped it.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *todoRevisionsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	tflog.Debug(ctx, "Preparing to read todo revisions data source")
+	var state todoRevisionsDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	id := state.ID.ValueInt64()
+
+	log, err := findRevisionLog(ctx, d.client, d.keyring, id)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Todo Revisions",
+			"Could not read the revision log of todo ID "+strconv.FormatInt(id, 10)+": "+err.Error(),
+		)
+		return
+	}
+
+	// Without a published log the todo is at its first revision, as long
+	// as it exists
+	if log == nil {
+		item, err := d.writer.readStored(id)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to Read Todo Revisions",
+				"Could not read todo ID "+strconv.FormatInt(id, 10)+": "+err.Error(),
+			)
+			return
+		}
+		if item == nil {
+			resp.Diagnostics.AddError(
+				"Todo Not Found",
+				"There is no todo with ID "+strconv.FormatInt(id, 10)+".",
+			)
+			return
+		}
+		log = &todoRevisionLog{Revision: 1}
+	}
+
+	// Map the log to model
+	state.Revision = types.Int64Value(log.Revision)
+	state.Revisions = []todoRevisionModel{}
+	for _, revision := range log.Revisions {
+		state.Revisions = append(state.Revisions, todoRevisionModel{
+			Revision:            types.Int64Value(revision.Revision),
+			ChangedAt:           types.StringValue(revision.ChangedAt),
+			PreviousDescription: types.StringPointerValue(revision.PreviousDescription),
+			PreviousCompleted:   types.BoolPointerValue(revision.PreviousCompleted),
+		})
+	}
+
+	// Set state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	tflog.Debug(ctx, "Finished reading todo revisions data source", map[string]any{"success": true})
+}
@@ -0,0 +1,54 @@
+package todo
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccTodoRevisionsDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// A todo Terraform has not changed has no revisions
+			{
+				Config: providerConfig + `
+resource "todo_todo" "test" {
+	description = "Go Shopping"
+	completed = false
+}
+
+data "todo_revisions" "test" {
+	id = todo_todo.test.id
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.todo_revisions.test", "revision", "1"),
+					resource.TestCheckResourceAttr("data.todo_revisions.test", "revisions.#", "0"),
+				),
+			},
+			// Changes Terraform makes are published
+			{
+				Config: providerConfig + `
+resource "todo_todo" "test" {
+	description = "Go shopping for avocados"
+	completed = false
+}
+
+data "todo_revisions" "test" {
+	id = todo_todo.test.id
+
+	depends_on = [todo_todo.test]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.todo_revisions.test", "revision", "2"),
+					resource.TestCheckResourceAttr("data.todo_revisions.test", "revisions.#", "1"),
+					resource.TestCheckResourceAttr("data.todo_revisions.test", "revisions.0.revision", "2"),
+					resource.TestCheckResourceAttr("data.todo_revisions.test", "revisions.0.previous_description", "Go Shopping"),
+					resource.TestCheckNoResourceAttr("data.todo_revisions.test", "revisions.0.previous_completed"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,192 @@
+package todo
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// cronMacros are the named schedules accepted in place of a cron expression.
+var cronMacros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// cronSearchYears bounds how far ahead cronSchedule.next looks, so
+// expressions that can never match, such as "0 0 30 2 *", end the search.
+const cronSearchYears = 5
+
+// recurrenceSchedule says when a recurring todo is next due: either a fixed
+// interval after the current instance was created, or the next time
+// matching a cron expression. The zero value is never due.
+type recurrenceSchedule struct {
+	interval time.Duration
+	cron     *cronSchedule
+}
+
+// cronSchedule is a parsed five field cron expression, evaluated in UTC.
+// Each field is a bit set of the values it matches.
+type cronSchedule struct {
+	minute, hour, dom, month, dow uint64
+	// As in cron, a day matches either the day of month or the day of week
+	// when both are restricted, and both otherwise.
+	domStar, dowStar bool
+}
+
+// parseRecurrenceSchedule parses a cron expression or an interval duration.
+// At most one of them may be given.
+func parseRecurrenceSchedule(cron, interval string) (recurrenceSchedule, error) {
+	var schedule recurrenceSchedule
+
+	switch {
+	case cron != "" && interval != "":
+		return schedule, errors.New("only one of a schedule and an interval can be given")
+	case cron != "":
+		parsed, err := parseCronSchedule(cron)
+		if err != nil {
+			return schedule, err
+		}
+		schedule.cron = parsed
+	case interval != "":
+		parsed, err := time.ParseDuration(interval)
+		if err != nil || parsed <= 0 {
+			return schedule, fmt.Errorf("the interval must be a positive duration such as '24h', got: '%s'", interval)
+		}
+		schedule.interval = parsed
+	}
+	return schedule, nil
+}
+
+// next returns when the instance created at created is due to be followed
+// by the next one, and false if it never is.
+func (s recurrenceSchedule) next(created time.Time) (time.Time, bool) {
+	switch {
+	case s.interval > 0:
+		return created.Add(s.interval).UTC(), true
+	case s.cron != nil:
+		return s.cron.next(created)
+	}
+	return time.Time{}, false
+}
+
+// parseCronSchedule parses a cron expression with minute, hour, day of
+// month, month and day of week fields, or one of cronMacros. Fields accept
+// "*", values, ranges such as "1-5", steps such as "*/15" and
+// comma-separated lists of these. Names of months and days are not
+// supported.
+func parseCronSchedule(expression string) (*cronSchedule, error) {
+	if macro, ok := cronMacros[strings.TrimSpace(expression)]; ok {
+		expression = macro
+	}
+
+	fields := strings.Fields(expression)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("a cron expression must have 5 fields, got %d in '%s'", len(fields), expression)
+	}
+
+	var schedule cronSchedule
+	var err error
+	if schedule.minute, err = parseCronField(fields[0], 0, 59); err != nil {
+		return nil, fmt.Errorf("minute: %w", err)
+	}
+	if schedule.hour, err = parseCronField(fields[1], 0, 23); err != nil {
+		return nil, fmt.Errorf("hour: %w", err)
+	}
+	if schedule.dom, err = parseCronField(fields[2], 1, 31); err != nil {
+		return nil, fmt.Errorf("day of month: %w", err)
+	}
+	if schedule.month, err = parseCronField(fields[3], 1, 12); err != nil {
+		return nil, fmt.Errorf("month: %w", err)
+	}
+	if schedule.dow, err = parseCronField(fields[4], 0, 7); err != nil {
+		return nil, fmt.Errorf("day of week: %w", err)
+	}
+	// Sunday is both 0 and 7
+	if schedule.dow&(1<<7) != 0 {
+		schedule.dow |= 1
+	}
+	schedule.domStar = strings.HasPrefix(fields[2], "*")
+	schedule.dowStar = strings.HasPrefix(fields[4], "*")
+	return &schedule, nil
+}
+
+// parseCronField parses one field of a cron expression into a bit set of
+// the values between lowest and highest that it matches.
+func parseCronField(field string, lowest, highest int) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(field, ",") {
+		rangePart, stepPart, hasStep := strings.Cut(part, "/")
+		step := 1
+		if hasStep {
+			var err error
+			step, err = strconv.Atoi(stepPart)
+			if err != nil || step < 1 {
+				return 0, fmt.Errorf("invalid step in '%s'", part)
+			}
+		}
+
+		low, high := lowest, highest
+		if rangePart != "*" {
+			lowPart, highPart, isRange := strings.Cut(rangePart, "-")
+			var err error
+			if low, err = strconv.Atoi(lowPart); err != nil {
+				return 0, fmt.Errorf("invalid value in '%s'", part)
+			}
+			high = low
+			if isRange {
+				if high, err = strconv.Atoi(highPart); err != nil {
+					return 0, fmt.Errorf("invalid value in '%s'", part)
+				}
+			} else if hasStep {
+				high = highest
+			}
+		}
+		if low < lowest || high > highest || low > high {
+			return 0, fmt.Errorf("'%s' is outside %d-%d", part, lowest, highest)
+		}
+
+		for value := low; value <= high; value += step {
+			bits |= 1 << value
+		}
+	}
+	return bits, nil
+}
+
+// next returns the first time after t that matches the schedule.
+func (c *cronSchedule) next(t time.Time) (time.Time, bool) {
+	t = t.UTC().Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(cronSearchYears, 0, 0)
+
+	for t.Before(limit) {
+		switch {
+		case c.month&(1<<int(t.Month())) == 0:
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+		case !c.dayMatches(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, time.UTC)
+		case c.hour&(1<<t.Hour()) == 0:
+			t = t.Truncate(time.Hour).Add(time.Hour)
+		case c.minute&(1<<t.Minute()) == 0:
+			t = t.Add(time.Minute)
+		default:
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// dayMatches reports whether the day of t matches the day of month and day
+// of week fields.
+func (c *cronSchedule) dayMatches(t time.Time) bool {
+	domMatch := c.dom&(1<<t.Day()) != 0
+	dowMatch := c.dow&(1<<int(t.Weekday())) != 0
+	if c.domStar || c.dowStar {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
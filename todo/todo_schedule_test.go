@@ -0,0 +1,77 @@
+package todo
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCronScheduleNext(t *testing.T) {
+	// A Wednesday
+	from := time.Date(2024, 5, 15, 10, 30, 0, 0, time.UTC)
+
+	testCases := []struct {
+		expression string
+		expected   time.Time
+	}{
+		{"*/15 * * * *", time.Date(2024, 5, 15, 10, 45, 0, 0, time.UTC)},
+		{"0 9 * * 1", time.Date(2024, 5, 20, 9, 0, 0, 0, time.UTC)},
+		{"0 9 * * 1-5", time.Date(2024, 5, 16, 9, 0, 0, 0, time.UTC)},
+		{"30 10 15 5 *", time.Date(2025, 5, 15, 10, 30, 0, 0, time.UTC)},
+		{"0 0 1,15 * 0", time.Date(2024, 5, 19, 0, 0, 0, 0, time.UTC)},
+		{"0 0 * * 7", time.Date(2024, 5, 19, 0, 0, 0, 0, time.UTC)},
+		{"0 0 29 2 *", time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{"@monthly", time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.expression, func(t *testing.T) {
+			schedule, err := parseCronSchedule(testCase.expression)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			next, ok := schedule.next(from)
+			if !ok || !next.Equal(testCase.expected) {
+				t.Errorf("expected %s, got %s (%t)", testCase.expected, next, ok)
+			}
+		})
+	}
+
+	schedule, err := parseCronSchedule("0 0 30 2 *")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if next, ok := schedule.next(from); ok {
+		t.Errorf("expected February 30th never to match, got %s", next)
+	}
+}
+
+func TestParseRecurrenceSchedule(t *testing.T) {
+	for _, expression := range []string{"* * * *", "60 * * * *", "* * 0 * *", "*/0 * * * *", "a * * * *", "5-1 * * * *"} {
+		if _, err := parseCronSchedule(expression); err == nil {
+			t.Errorf("expected %q to be invalid", expression)
+		}
+	}
+
+	if _, err := parseRecurrenceSchedule("@daily", "24h"); err == nil {
+		t.Error("expected a schedule and an interval together to be invalid")
+	}
+	if _, err := parseRecurrenceSchedule("", "-1h"); err == nil {
+		t.Error("expected a negative interval to be invalid")
+	}
+
+	created := time.Date(2024, 5, 15, 10, 30, 0, 0, time.UTC)
+	schedule, err := parseRecurrenceSchedule("", "36h")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if next, ok := schedule.next(created); !ok || !next.Equal(created.Add(36*time.Hour)) {
+		t.Errorf("unexpected next time %s (%t)", next, ok)
+	}
+
+	schedule, err = parseRecurrenceSchedule("", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := schedule.next(created); ok {
+		t.Error("expected no schedule never to be due")
+	}
+}
@@ -0,0 +1,284 @@
+package todo
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"unicode"
+
+	// Todo API Libraries
+	"github.com/spkane/todo-for-terraform/client"
+
+	"github.com/agext/levenshtein"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	// defaultSearchLimit is how many results todo_search returns by default.
+	defaultSearchLimit = 10
+	// defaultSearchMinScore is the lowest score todo_search returns by default.
+	defaultSearchMinScore = 0.5
+
+	// searchTokenMatch is how similar two words must be to count as the same
+	// word, allowing about one typo in four letters.
+	searchTokenMatch = 0.75
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &todoSearchDataSource{}
+	_ datasource.DataSourceWithConfigure = &todoSearchDataSource{}
+)
+
+// NewTodoSearchDataSource is a helper function to simplify the provider implementation.
+func NewTodoSearchDataSource() datasource.DataSource {
+	return &todoSearchDataSource{}
+}
+
+// todoSearchDataSource is the data source implementation.
+type todoSearchDataSource struct {
+	client  *client.TodoList
+	keyring *todoKeyring
+}
+
+// todoSearchDataSourceModel maps the data source schema data.
+type todoSearchDataSourceModel struct {
+	Query    types.String            `tfsdk:"query"`
+	Limit    types.Int64             `tfsdk:"limit"`
+	MinScore types.Float64           `tfsdk:"min_score"`
+	Results  []todoSearchResultModel `tfsdk:"results"`
+	IDs      []types.Int64           `tfsdk:"ids"`
+}
+
+// todoSearchResultModel maps a ranked todo in the results attribute.
+type todoSearchResultModel struct {
+	ID          types.Int64   `tfsdk:"id"`
+	Description types.String  `tfsdk:"description"`
+	Completed   types.Bool    `tfsdk:"completed"`
+	Score       types.Float64 `tfsdk:"score"`
+}
+
+// todoSearchResult is a todo with its score against a query.
+type todoSearchResult struct {
+	todo  todoItem
+	score float64
+}
+
+// searchTokens splits text into lower case words.
+func searchTokens(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+}
+
+// searchScore rates how well a description matches a query, from 0 to 1.
+//
+// The score is the mean of two parts: the share of query words found in the
+// description, and how closely each query word resembles its nearest word in
+// the description by Levenshtein distance. Words at least searchTokenMatch
+// alike count as found, so a typo costs little, while the second part still
+// ranks exact words first.
+func searchScore(queryTokens []string, description string) float64 {
+	descriptionTokens := searchTokens(description)
+	if len(queryTokens) == 0 || len(descriptionTokens) == 0 {
+		return 0
+	}
+
+	var overlap, similarity float64
+	for _, queryToken := range queryTokens {
+		best := 0.0
+		for _, descriptionToken := range descriptionTokens {
+			if s := tokenSimilarity(queryToken, descriptionToken); s > best {
+				best = s
+			}
+		}
+		if best >= searchTokenMatch {
+			overlap++
+		}
+		similarity += best
+	}
+
+	n := float64(len(queryTokens))
+	return (overlap/n + similarity/n) / 2
+}
+
+// tokenSimilarity is one minus the Levenshtein distance between two words
+// relative to the longer of them.
+func tokenSimilarity(a, b string) float64 {
+	if a == b {
+		return 1
+	}
+	longest := len([]rune(a))
+	if l := len([]rune(b)); l > longest {
+		longest = l
+	}
+	return 1 - float64(levenshtein.Distance(a, b, nil))/float64(longest)
+}
+
+// rankTodos scores todos against a query and returns up to limit of those
+// scoring at least minScore, best first and then by ID.
+func rankTodos(query string, todos []todoItem, minScore float64, limit int) []todoSearchResult {
+	queryTokens := searchTokens(query)
+
+	var results []todoSearchResult
+	for _, todo := range todos {
+		score := searchScore(queryTokens, todo.Description)
+		if score > 0 && score >= minScore {
+			results = append(results, todoSearchResult{todo: todo, score: score})
+		}
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].score != results[j].score {
+			return results[i].score > results[j].score
+		}
+		return results[i].todo.ID < results[j].todo.ID
+	})
+	if len(results) > limit {
+		results = results[:limit]
+	}
+	return results
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *todoSearchDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData := req.ProviderData.(*todoProviderData)
+	d.client = providerData.client
+	d.keyring = providerData.keyring
+}
+
+// Metadata returns the data source type name.
+func (d *todoSearchDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_search"
+}
+
+// Schema defines the schema for the data source.
+func (d *todoSearchDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Search todo descriptions for words, tolerating typos. " +
+			"Each todo is scored from 0 to 1 by the share of query words its description contains and by how closely " +
+			"the query words resemble its words by Levenshtein distance.",
+		Attributes: map[string]schema.Attribute{
+			"query": schema.StringAttribute{
+				Description: "The words to search for. Letter case and punctuation are ignored.",
+				Required:    true,
+			},
+			"limit": schema.Int64Attribute{
+				Description: "The most results to return (default: 10).",
+				Optional:    true,
+			},
+			"min_score": schema.Float64Attribute{
+				Description: "The lowest score to return, from 0 to 1 (default: 0.5).",
+				Optional:    true,
+			},
+			"results": schema.ListNestedAttribute{
+				Description: "The best matching todos, highest score first.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.Int64Attribute{
+							Description: "The unique identifier for the todo.",
+							Computed:    true,
+						},
+						"description": schema.StringAttribute{
+							Description: "The description for the todo.",
+							Computed:    true,
+						},
+						"completed": schema.BoolAttribute{
+							Description: "The completed status for the todo.",
+							Computed:    true,
+						},
+						"score": schema.Float64Attribute{
+							Description: "How well the description matches the query, from 0 to 1.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"ids": schema.ListAttribute{
+				Description: "The unique identifiers of the results, highest score first.",
+				Computed:    true,
+				ElementType: types.Int64Type,
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *todoSearchDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	tflog.Debug(ctx, "Preparing to read search data source")
+	var state todoSearchDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if len(searchTokens(state.Query.ValueString())) == 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("query"),
+			"Invalid Search Query",
+			"The query must contain at least one letter or number.",
+		)
+	}
+	limit := int64(defaultSearchLimit)
+	if !state.Limit.IsNull() {
+		limit = state.Limit.ValueInt64()
+		if limit < 1 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("limit"),
+				"Invalid Search Limit",
+				"The limit must be at least 1.",
+			)
+		}
+	}
+	minScore := defaultSearchMinScore
+	if !state.MinScore.IsNull() {
+		minScore = state.MinScore.ValueFloat64()
+		if minScore < 0 || minScore > 1 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("min_score"),
+				"Invalid Minimum Score",
+				"The min_score must be between 0 and 1.",
+			)
+		}
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	all, diags := listTodos(ctx, d.client, d.keyring, todoFilter{}, 0)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	results := rankTodos(state.Query.ValueString(), all, minScore, int(limit))
+
+	// Map response body to model
+	state.Results = []todoSearchResultModel{}
+	state.IDs = []types.Int64{}
+	for _, result := range results {
+		state.Results = append(state.Results, todoSearchResultModel{
+			ID:          types.Int64Value(result.todo.ID),
+			Description: types.StringValue(result.todo.Description),
+			Completed:   types.BoolValue(result.todo.Completed),
+			Score:       types.Float64Value(result.score),
+		})
+		state.IDs = append(state.IDs, types.Int64Value(result.todo.ID))
+	}
+
+	// Set state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	tflog.Debug(ctx, "Finished reading search data source", map[string]any{
+		"searched": len(all),
+		"results":  len(results)})
+}
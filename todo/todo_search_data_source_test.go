@@ -0,0 +1,67 @@
+package todo
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccTodoSearchDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: providerConfig + `
+resource "todo_todo" "test" {
+	description = "Renew the acceptance certificate"
+	completed = false
+}
+
+data "todo_search" "test" {
+	query = "renw acceptance certificate"
+	limit = 1
+
+	depends_on = [todo_todo.test]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.todo_search.test", "results.#", "1"),
+					resource.TestCheckResourceAttrPair("data.todo_search.test", "results.0.id", "todo_todo.test", "id"),
+					resource.TestCheckResourceAttr("data.todo_search.test", "results.0.description", "Renew the acceptance certificate"),
+					resource.TestCheckResourceAttrSet("data.todo_search.test", "results.0.score"),
+					resource.TestCheckResourceAttrPair("data.todo_search.test", "ids.0", "todo_todo.test", "id"),
+				),
+			},
+		},
+	})
+}
+
+func TestRankTodos(t *testing.T) {
+	todos := []todoItem{
+		{ID: 1, Description: "Walk the dog"},
+		{ID: 2, Description: "Go Shopping"},
+		{ID: 3, Description: "Go shopping for shoes"},
+		{ID: 4, Description: "Shopping, go!"},
+	}
+
+	results := rankTodos("go shoping", todos, 0.5, 10)
+	if len(results) != 3 {
+		t.Fatalf("expected 3 results, got %v", results)
+	}
+	for i, id := range []int64{2, 3, 4} {
+		if results[i].todo.ID != id {
+			t.Errorf("expected result %d to be todo %d, got %d", i, id, results[i].todo.ID)
+		}
+	}
+	if results[0].score != results[1].score {
+		t.Errorf("expected equal scores for extra words, got %v and %v", results[0].score, results[1].score)
+	}
+
+	if results := rankTodos("go shopping", todos, 0.5, 1); len(results) != 1 || results[0].score != 1 {
+		t.Errorf("expected a single exact match, got %v", results)
+	}
+	if results := rankTodos("quantum", todos, 0.5, 10); len(results) != 0 {
+		t.Errorf("expected no results, got %v", results)
+	}
+}
@@ -0,0 +1,108 @@
+package todo
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &todoServerDataSource{}
+	_ datasource.DataSourceWithConfigure = &todoServerDataSource{}
+)
+
+// NewTodoServerDataSource is a helper function to simplify the provider implementation.
+func NewTodoServerDataSource() datasource.DataSource {
+	return &todoServerDataSource{}
+}
+
+// todoServerDataSource is the data source implementation.
+type todoServerDataSource struct {
+	probe *todoServerProbe
+}
+
+// todoServerDataSourceModel maps the data source schema data.
+type todoServerDataSourceModel struct {
+	Endpoint   types.String `tfsdk:"endpoint"`
+	LatencyMS  types.Int64  `tfsdk:"latency_ms"`
+	MediaType  types.String `tfsdk:"media_type"`
+	APIVersion types.String `tfsdk:"api_version"`
+	ProbedAt   types.String `tfsdk:"probed_at"`
+}
+
+// Configure adds the provider's server probe to the data source.
+func (d *todoServerDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData := req.ProviderData.(*todoProviderData)
+	d.probe = providerData.serverProbe
+}
+
+// Metadata returns the data source type name.
+func (d *todoServerDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_server"
+}
+
+// Schema defines the schema for the data source.
+func (d *todoServerDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Fetch what the provider learned about the Todo server when it was configured. " +
+			"The server is probed once per provider configuration; reading this data source does not contact it again. " +
+			"The provider fails to configure when the server does not answer the probe, so it was reachable whenever this data source can be read.",
+		Attributes: map[string]schema.Attribute{
+			"endpoint": schema.StringAttribute{
+				Description: "The URL of the Todo API, resolved from the provider configuration and environment.",
+				Computed:    true,
+			},
+			"latency_ms": schema.Int64Attribute{
+				Description: "How long the probe took, in milliseconds.",
+				Computed:    true,
+			},
+			"media_type": schema.StringAttribute{
+				Description: "The media type of the server's response, such as `application/spkane.todo-list.v1+json`.",
+				Computed:    true,
+			},
+			"api_version": schema.StringAttribute{
+				Description: "The API version named by the media type, such as `v1`, or an empty string if it names none.",
+				Computed:    true,
+			},
+			"probed_at": schema.StringAttribute{
+				Description: "When the server was probed, as an RFC 3339 timestamp.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *todoServerDataSource) Read(ctx context.Context, _ datasource.ReadRequest, resp *datasource.ReadResponse) {
+	tflog.Debug(ctx, "Preparing to read server data source")
+
+	if d.probe == nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Todo Server",
+			"The provider has not probed the Todo server. Ensure the provider is configured.",
+		)
+		return
+	}
+
+	// Map probe result to model
+	state := todoServerDataSourceModel{
+		Endpoint:   types.StringValue(d.probe.endpoint),
+		LatencyMS:  types.Int64Value(d.probe.latency.Milliseconds()),
+		MediaType:  types.StringValue(d.probe.mediaType),
+		APIVersion: types.StringValue(d.probe.apiVersion),
+		ProbedAt:   types.StringValue(d.probe.probedAt.Format(time.RFC3339)),
+	}
+
+	// Set state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	tflog.Debug(ctx, "Finished reading server data source")
+}
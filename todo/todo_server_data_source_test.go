@@ -0,0 +1,26 @@
+package todo
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccTodoServerDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: providerConfig + `data "todo_server" "test" {}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.todo_server.test", "endpoint", "http://127.0.0.1:8080/"),
+					resource.TestCheckResourceAttr("data.todo_server.test", "media_type", "application/spkane.todo-list.v1+json"),
+					resource.TestCheckResourceAttr("data.todo_server.test", "api_version", "v1"),
+					resource.TestCheckResourceAttrSet("data.todo_server.test", "latency_ms"),
+					resource.TestCheckResourceAttrSet("data.todo_server.test", "probed_at"),
+				),
+			},
+		},
+	})
+}
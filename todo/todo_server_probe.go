@@ -0,0 +1,79 @@
+package todo
+
+import (
+	"context"
+	"mime"
+	"net/http"
+	"regexp"
+	"time"
+
+	// Todo API Libraries
+	"github.com/spkane/todo-for-terraform/client"
+	"github.com/spkane/todo-for-terraform/client/todos"
+)
+
+// mediaTypeVersionPattern extracts the API version from a vendor media type
+// such as application/spkane.todo-list.v1+json.
+var mediaTypeVersionPattern = regexp.MustCompile(`\.(v\d+)\+json$`)
+
+// todoServerProbe is the result of the capability probe the provider runs
+// against the Todo server once, when it is configured.
+type todoServerProbe struct {
+	endpoint   string
+	latency    time.Duration
+	mediaType  string
+	apiVersion string
+	probedAt   time.Time
+	err        error
+}
+
+// probeTodoServer lists a single todo to check that the server is reachable,
+// timing the request and recording the media type of the response.
+func probeTodoServer(ctx context.Context, c *client.TodoList, endpoint string) *todoServerProbe {
+	recorder := &responseRecorder{next: http.DefaultTransport}
+
+	params := todos.NewFindTodosParamsWithContext(ctx)
+	params.SetHTTPClient(&http.Client{Transport: recorder})
+	var limit int32 = 1
+	params.SetLimit(&limit)
+
+	probe := &todoServerProbe{
+		endpoint: endpoint,
+		probedAt: time.Now().UTC(),
+	}
+	start := time.Now()
+	_, probe.err = c.Todos.FindTodos(params)
+	probe.latency = time.Since(start)
+
+	if recorder.response != nil {
+		probe.mediaType, _, _ = mime.ParseMediaType(recorder.response.Header.Get("Content-Type"))
+		probe.apiVersion = apiVersionFromMediaType(probe.mediaType)
+	}
+	return probe
+}
+
+// apiVersionFromMediaType returns the API version named by a vendor media
+// type, or an empty string if it does not name one.
+func apiVersionFromMediaType(mediaType string) string {
+	match := mediaTypeVersionPattern.FindStringSubmatch(mediaType)
+	if match == nil {
+		return ""
+	}
+	return match[1]
+}
+
+// responseRecorder is an http.RoundTripper that keeps the last response it
+// saw, so the probe can inspect headers the generated client discards.
+type responseRecorder struct {
+	next     http.RoundTripper
+	response *http.Response
+}
+
+// RoundTrip implements http.RoundTripper.
+func (r *responseRecorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := r.next.RoundTrip(req)
+	if err == nil {
+		r.response = resp
+	}
+	return resp, err
+}
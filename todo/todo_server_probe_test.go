@@ -0,0 +1,40 @@
+package todo
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestProbeTodoServer(t *testing.T) {
+	c := newTestTodoClient(t, func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/spkane.todo-list.v1+json; charset=utf-8")
+		_, _ = w.Write([]byte(`[]`))
+	})
+
+	probe := probeTodoServer(context.Background(), c, "http://example.com/")
+	if probe.err != nil {
+		t.Fatalf("unexpected error: %v", probe.err)
+	}
+	if probe.mediaType != "application/spkane.todo-list.v1+json" {
+		t.Errorf("unexpected media type %q", probe.mediaType)
+	}
+	if probe.apiVersion != "v1" {
+		t.Errorf("unexpected API version %q", probe.apiVersion)
+	}
+}
+
+func TestApiVersionFromMediaType(t *testing.T) {
+	testCases := map[string]string{
+		"application/spkane.todo-list.v1+json":  "v1",
+		"application/spkane.todo-list.v12+json": "v12",
+		"application/json":                      "",
+		"":                                      "",
+	}
+
+	for mediaType, expected := range testCases {
+		if got := apiVersionFromMediaType(mediaType); got != expected {
+			t.Errorf("apiVersionFromMediaType(%q) = %q, expected %q", mediaType, got, expected)
+		}
+	}
+}
@@ -0,0 +1,142 @@
+package todo
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	// Todo API Libraries
+	"github.com/spkane/todo-for-terraform/client"
+	"github.com/spkane/todo-for-terraform/models"
+)
+
+// todoSnapshotVersion is the version of the snapshot format that is written,
+// and the only one that can be restored.
+const todoSnapshotVersion = 1
+
+// todoSnapshot is a copy of every todo on a server. Descriptions are kept
+// exactly as stored, so encrypted descriptions stay encrypted and a restore
+// needs no keys.
+type todoSnapshot struct {
+	Version   int                `json:"version"`
+	CreatedAt time.Time          `json:"created_at"`
+	Checksum  string             `json:"checksum"`
+	Todos     []todoSnapshotItem `json:"todos"`
+}
+
+// todoSnapshotItem is a todo in a snapshot.
+type todoSnapshotItem struct {
+	ID          int64  `json:"id"`
+	Description string `json:"description"`
+	Completed   bool   `json:"completed"`
+}
+
+// newTodoSnapshot pages through every todo on the server and returns them
+// as a snapshot, in ascending ID order.
+func newTodoSnapshot(ctx context.Context, c *client.TodoList) (*todoSnapshot, error) {
+	items, err := listStoredTodos(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+
+	checksum, err := todoSnapshotChecksum(items)
+	if err != nil {
+		return nil, err
+	}
+	return &todoSnapshot{
+		Version:   todoSnapshotVersion,
+		CreatedAt: time.Now().UTC().Truncate(time.Second),
+		Checksum:  checksum,
+		Todos:     items,
+	}, nil
+}
+
+// listStoredTodos pages through every todo on the server and returns them
+// with their descriptions as stored, in ascending ID order.
+func listStoredTodos(ctx context.Context, c *client.TodoList) ([]todoSnapshotItem, error) {
+	items := []todoSnapshotItem{}
+	err := forEachTodo(ctx, c, 0, func(item *models.Item) bool {
+		if item.Description != nil && item.Completed != nil {
+			items = append(items, todoSnapshotItem{
+				ID:          item.ID,
+				Description: *item.Description,
+				Completed:   *item.Completed,
+			})
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(items, func(i, j int) bool { return items[i].ID < items[j].ID })
+	return items, nil
+}
+
+// todoSnapshotChecksum returns the SHA-256 checksum of the JSON encoding of
+// the todos in a snapshot.
+func todoSnapshotChecksum(items []todoSnapshotItem) (string, error) {
+	encoded, err := json.Marshal(items)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(encoded)
+	return "sha256:" + hex.EncodeToString(sum[:]), nil
+}
+
+// writeTodoSnapshot writes a snapshot to a file, readable only by its
+// owner. The file is replaced atomically, so an interrupted write leaves any
+// previous snapshot intact.
+func writeTodoSnapshot(path string, snapshot *todoSnapshot) error {
+	encoded, err := json.MarshalIndent(snapshot, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	file, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+
+	if _, err := file.Write(append(encoded, '\n')); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	return os.Rename(file.Name(), path)
+}
+
+// readTodoSnapshot reads a snapshot from a file and checks its version and
+// checksum.
+func readTodoSnapshot(path string) (*todoSnapshot, error) {
+	encoded, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var snapshot todoSnapshot
+	if err := json.Unmarshal(encoded, &snapshot); err != nil {
+		return nil, fmt.Errorf("not a todo snapshot: %w", err)
+	}
+	if snapshot.Version != todoSnapshotVersion {
+		return nil, fmt.Errorf("unsupported snapshot version %d, only version %d is supported", snapshot.Version, todoSnapshotVersion)
+	}
+
+	checksum, err := todoSnapshotChecksum(snapshot.Todos)
+	if err != nil {
+		return nil, err
+	}
+	if checksum != snapshot.Checksum {
+		return nil, errors.New("the snapshot checksum does not match its todos, the file is corrupt or was edited")
+	}
+	return &snapshot, nil
+}
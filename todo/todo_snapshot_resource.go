@@ -0,0 +1,184 @@
+package todo
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"time"
+
+	// Todo API Libraries
+	"github.com/spkane/todo-for-terraform/client"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource              = &todoSnapshotResource{}
+	_ resource.ResourceWithConfigure = &todoSnapshotResource{}
+)
+
+// NewTodoSnapshotResource is a helper function to simplify the provider implementation.
+func NewTodoSnapshotResource() resource.Resource {
+	return &todoSnapshotResource{}
+}
+
+// todoSnapshotResource is the resource implementation.
+type todoSnapshotResource struct {
+	client *client.TodoList
+}
+
+// todoSnapshotResourceModel maps the resource schema data.
+type todoSnapshotResourceModel struct {
+	ID        types.String `tfsdk:"id"`
+	Path      types.String `tfsdk:"path"`
+	Triggers  types.Map    `tfsdk:"triggers"`
+	TodoCount types.Int64  `tfsdk:"todo_count"`
+	Checksum  types.String `tfsdk:"checksum"`
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *todoSnapshotResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	r.client = req.ProviderData.(*todoProviderData).client
+}
+
+// Metadata returns the resource type name.
+func (r *todoSnapshotResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_snapshot"
+}
+
+// Schema defines the schema for the resource.
+func (r *todoSnapshotResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Back up every todo on the Todo server to a local JSON file, which todo_restore can restore. Descriptions " +
+			"are saved exactly as stored, so encrypted descriptions stay encrypted. The snapshot is taken when the resource is " +
+			"created and again whenever it is replaced, or when the file is missing or was changed. Destroying the resource " +
+			"leaves the file in place.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The time the snapshot was taken, in RFC 3339 format.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"path": schema.StringAttribute{
+				Description: "The path of the snapshot file. It is written readable only by its owner.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"triggers": schema.MapAttribute{
+				Description: "Arbitrary values that take the snapshot again when they change.",
+				Optional:    true,
+				ElementType: types.StringType,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"todo_count": schema.Int64Attribute{
+				Description: "The number of todos in the snapshot.",
+				Computed:    true,
+			},
+			"checksum": schema.StringAttribute{
+				Description: "The SHA-256 checksum of the todos in the snapshot, which is also stored in the file.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// Create takes the snapshot and writes it to the file.
+func (r *todoSnapshotResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	tflog.Debug(ctx, "Preparing to create todo snapshot resource")
+	// Retrieve values from plan
+	var plan todoSnapshotResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	snapshot, err := newTodoSnapshot(ctx, r.client)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Todos",
+			err.Error(),
+		)
+		return
+	}
+	if err := writeTodoSnapshot(plan.Path.ValueString(), snapshot); err != nil {
+		resp.Diagnostics.AddError(
+			"Error Writing Snapshot",
+			"Could not write snapshot to "+plan.Path.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+	tflog.Info(ctx, "Wrote todo snapshot", map[string]any{"path": plan.Path.ValueString(), "count": len(snapshot.Todos)})
+
+	plan.ID = types.StringValue(snapshot.CreatedAt.Format(time.RFC3339))
+	plan.TodoCount = types.Int64Value(int64(len(snapshot.Todos)))
+	plan.Checksum = types.StringValue(snapshot.Checksum)
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	tflog.Debug(ctx, "Created todo snapshot resource", map[string]any{"success": !resp.Diagnostics.HasError()})
+}
+
+// Read checks that the file still holds the snapshot. A missing or changed
+// file removes the resource from state, so the next apply takes the
+// snapshot again.
+func (r *todoSnapshotResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	tflog.Debug(ctx, "Preparing to read todo snapshot resource")
+	// Get current state
+	var state todoSnapshotResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	snapshot, err := readTodoSnapshot(state.Path.ValueString())
+	if errors.Is(err, fs.ErrNotExist) {
+		tflog.Debug(ctx, "Snapshot file no longer exists", map[string]any{"path": state.Path.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil || snapshot.Checksum != state.Checksum.ValueString() {
+		tflog.Warn(ctx, "Snapshot file no longer holds the snapshot", map[string]any{"path": state.Path.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	tflog.Debug(ctx, "Finished reading todo snapshot resource", map[string]any{"success": true})
+}
+
+// Update is never called, as every configurable attribute requires replacement.
+func (r *todoSnapshotResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan todoSnapshotResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete removes the snapshot from state, leaving the file in place.
+func (r *todoSnapshotResource) Delete(ctx context.Context, _ resource.DeleteRequest, _ *resource.DeleteResponse) {
+	tflog.Debug(ctx, "Deleted todo snapshot resource", map[string]any{"success": true})
+}
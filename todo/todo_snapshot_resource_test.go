@@ -0,0 +1,41 @@
+package todo
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccTodoSnapshotResource(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "todos.json")
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: providerConfig + fmt.Sprintf(`
+resource "todo_todo" "test" {
+	description = "snapshot acceptance test"
+	completed   = false
+}
+
+resource "todo_snapshot" "test" {
+	path = %q
+
+	depends_on = [todo_todo.test]
+}
+`, path),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("todo_snapshot.test", "path", path),
+					resource.TestCheckResourceAttrSet("todo_snapshot.test", "id"),
+					resource.TestCheckResourceAttrSet("todo_snapshot.test", "todo_count"),
+					resource.TestCheckResourceAttrSet("todo_snapshot.test", "checksum"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
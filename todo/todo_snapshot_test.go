@@ -0,0 +1,82 @@
+package todo
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testTodoSnapshot(t *testing.T) *todoSnapshot {
+	items := []todoSnapshotItem{
+		{ID: 1, Description: "Go Shopping", Completed: false},
+		{ID: 4, Description: "Walk the dog", Completed: true},
+	}
+	checksum, err := todoSnapshotChecksum(items)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return &todoSnapshot{
+		Version:   todoSnapshotVersion,
+		CreatedAt: time.Date(2024, 6, 30, 17, 0, 0, 0, time.UTC),
+		Checksum:  checksum,
+		Todos:     items,
+	}
+}
+
+func TestTodoSnapshotRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "todos.json")
+	snapshot := testTodoSnapshot(t)
+	if err := writeTodoSnapshot(path, snapshot); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if info.Mode().Perm() != 0o600 {
+		t.Errorf("expected the snapshot to be readable only by its owner, got %s", info.Mode().Perm())
+	}
+
+	read, err := readTodoSnapshot(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if read.Checksum != snapshot.Checksum || !read.CreatedAt.Equal(snapshot.CreatedAt) || len(read.Todos) != 2 || read.Todos[1] != snapshot.Todos[1] {
+		t.Errorf("unexpected snapshot %+v", read)
+	}
+}
+
+func TestReadTodoSnapshotRejectsChanges(t *testing.T) {
+	dir := t.TempDir()
+
+	tampered := testTodoSnapshot(t)
+	tampered.Todos[0].Completed = true
+	tamperedPath := filepath.Join(dir, "tampered.json")
+	if err := writeTodoSnapshot(tamperedPath, tampered); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := readTodoSnapshot(tamperedPath); err == nil || !strings.Contains(err.Error(), "checksum") {
+		t.Errorf("expected a checksum error, got %v", err)
+	}
+
+	future := testTodoSnapshot(t)
+	future.Version = todoSnapshotVersion + 1
+	futurePath := filepath.Join(dir, "future.json")
+	if err := writeTodoSnapshot(futurePath, future); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := readTodoSnapshot(futurePath); err == nil || !strings.Contains(err.Error(), "version") {
+		t.Errorf("expected a version error, got %v", err)
+	}
+
+	garbagePath := filepath.Join(dir, "garbage.json")
+	if err := os.WriteFile(garbagePath, []byte("- not json"), 0o600); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := readTodoSnapshot(garbagePath); err == nil {
+		t.Error("expected an error reading a file that is not a snapshot")
+	}
+}
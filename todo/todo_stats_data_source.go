@@ -0,0 +1,165 @@
+package todo
+
+import (
+	"context"
+
+	// Todo API Libraries
+	"github.com/spkane/todo-for-terraform/client"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &todoStatsDataSource{}
+	_ datasource.DataSourceWithConfigure = &todoStatsDataSource{}
+)
+
+// NewTodoStatsDataSource is a helper function to simplify the provider implementation.
+func NewTodoStatsDataSource() datasource.DataSource {
+	return &todoStatsDataSource{}
+}
+
+// todoStatsDataSource is the data source implementation.
+type todoStatsDataSource struct {
+	client  *client.TodoList
+	keyring *todoKeyring
+}
+
+// todoStatsDataSourceModel maps the data source schema data.
+type todoStatsDataSourceModel struct {
+	DescriptionPrefix types.String  `tfsdk:"description_prefix"`
+	Total             types.Int64   `tfsdk:"total"`
+	Completed         types.Int64   `tfsdk:"completed"`
+	Open              types.Int64   `tfsdk:"open"`
+	CompletionRatio   types.Float64 `tfsdk:"completion_ratio"`
+	HighestID         types.Int64   `tfsdk:"highest_id"`
+	LowestID          types.Int64   `tfsdk:"lowest_id"`
+}
+
+// todoStats are the aggregates computed over a set of todos. The IDs are
+// only meaningful when total is positive.
+type todoStats struct {
+	total     int64
+	completed int64
+	highestID int64
+	lowestID  int64
+}
+
+// newTodoStats computes the aggregates for a set of todos.
+func newTodoStats(todos []todoItem) todoStats {
+	var stats todoStats
+	for _, todo := range todos {
+		if stats.total == 0 || todo.ID > stats.highestID {
+			stats.highestID = todo.ID
+		}
+		if stats.total == 0 || todo.ID < stats.lowestID {
+			stats.lowestID = todo.ID
+		}
+		stats.total++
+		if todo.Completed {
+			stats.completed++
+		}
+	}
+	return stats
+}
+
+// completionRatio returns the share of completed todos, from 0 to 1. With no
+// todos nothing is left to do, so the ratio is 1.
+func (s todoStats) completionRatio() float64 {
+	if s.total == 0 {
+		return 1
+	}
+	return float64(s.completed) / float64(s.total)
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *todoStatsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData := req.ProviderData.(*todoProviderData)
+	d.client = providerData.client
+	d.keyring = providerData.keyring
+}
+
+// Metadata returns the data source type name.
+func (d *todoStatsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_stats"
+}
+
+// Schema defines the schema for the data source.
+func (d *todoStatsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Fetch aggregate counts over the todos on the server.",
+		Attributes: map[string]schema.Attribute{
+			"description_prefix": schema.StringAttribute{
+				Description: "Only count todos whose description starts with this text.",
+				Optional:    true,
+			},
+			"total": schema.Int64Attribute{
+				Description: "The number of todos.",
+				Computed:    true,
+			},
+			"completed": schema.Int64Attribute{
+				Description: "The number of completed todos.",
+				Computed:    true,
+			},
+			"open": schema.Int64Attribute{
+				Description: "The number of todos that are not completed.",
+				Computed:    true,
+			},
+			"completion_ratio": schema.Float64Attribute{
+				Description: "The share of todos that are completed, from 0 to 1. This is 1 when there are no todos.",
+				Computed:    true,
+			},
+			"highest_id": schema.Int64Attribute{
+				Description: "The highest todo ID, or null when there are no todos.",
+				Computed:    true,
+			},
+			"lowest_id": schema.Int64Attribute{
+				Description: "The lowest todo ID, or null when there are no todos.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *todoStatsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	tflog.Debug(ctx, "Preparing to read stats data source")
+	var state todoStatsDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	filter := todoFilter{descriptionPrefix: state.DescriptionPrefix.ValueString()}
+	matched, diags := listTodos(ctx, d.client, d.keyring, filter, 0)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Map response body to model
+	stats := newTodoStats(matched)
+	state.Total = types.Int64Value(stats.total)
+	state.Completed = types.Int64Value(stats.completed)
+	state.Open = types.Int64Value(stats.total - stats.completed)
+	state.CompletionRatio = types.Float64Value(stats.completionRatio())
+	state.HighestID = types.Int64Null()
+	state.LowestID = types.Int64Null()
+	if stats.total > 0 {
+		state.HighestID = types.Int64Value(stats.highestID)
+		state.LowestID = types.Int64Value(stats.lowestID)
+	}
+
+	// Set state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	tflog.Debug(ctx, "Finished reading stats data source", map[string]any{"total": stats.total})
+}
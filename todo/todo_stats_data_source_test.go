@@ -0,0 +1,67 @@
+package todo
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccTodoStatsDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: providerConfig + `
+resource "todo_todo" "open" {
+	description = "[acc-stats] Go Shopping"
+	completed = false
+}
+
+resource "todo_todo" "done" {
+	description = "[acc-stats] Walk the dog"
+	completed = true
+
+	depends_on = [todo_todo.open]
+}
+
+data "todo_stats" "test" {
+	description_prefix = "[acc-stats]"
+
+	depends_on = [todo_todo.open, todo_todo.done]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.todo_stats.test", "total", "2"),
+					resource.TestCheckResourceAttr("data.todo_stats.test", "completed", "1"),
+					resource.TestCheckResourceAttr("data.todo_stats.test", "open", "1"),
+					resource.TestCheckResourceAttr("data.todo_stats.test", "completion_ratio", "0.5"),
+					resource.TestCheckResourceAttrPair("data.todo_stats.test", "lowest_id", "todo_todo.open", "id"),
+					resource.TestCheckResourceAttrPair("data.todo_stats.test", "highest_id", "todo_todo.done", "id"),
+				),
+			},
+		},
+	})
+}
+
+func TestNewTodoStats(t *testing.T) {
+	stats := newTodoStats([]todoItem{
+		{ID: 7, Completed: true},
+		{ID: 3, Completed: false},
+		{ID: 12, Completed: true},
+		{ID: 5, Completed: true},
+	})
+	if stats.total != 4 || stats.completed != 3 {
+		t.Errorf("expected 3 of 4 completed, got %d of %d", stats.completed, stats.total)
+	}
+	if stats.lowestID != 3 || stats.highestID != 12 {
+		t.Errorf("expected IDs 3 to 12, got %d to %d", stats.lowestID, stats.highestID)
+	}
+	if ratio := stats.completionRatio(); ratio != 0.75 {
+		t.Errorf("expected a completion ratio of 0.75, got %v", ratio)
+	}
+
+	if ratio := newTodoStats(nil).completionRatio(); ratio != 1 {
+		t.Errorf("expected a completion ratio of 1 without todos, got %v", ratio)
+	}
+}
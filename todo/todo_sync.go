@@ -0,0 +1,243 @@
+package todo
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+
+	// Todo API Libraries
+	"github.com/spkane/todo-for-terraform/models"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// todoSyncTarget is a todo a sync resource wants on the server. The key
+// identifies it across applies.
+type todoSyncTarget struct {
+	Key         string
+	Description string
+	Completed   bool
+}
+
+// todoSyncEntry is a todo a sync resource manages.
+type todoSyncEntry struct {
+	Key         string
+	ID          int64
+	Description string
+	Completed   bool
+}
+
+// sync makes the todos on the server match targets, given the entries that
+// already have todos. Entries whose key is no longer a target are deleted
+// first, then targets are created or updated. At most parallelism todos are
+// written at a time; a parallelism of 1 creates todos in target order.
+//
+// Failures are reported as diagnostics without stopping the sync. The
+// returned entries are the todos that exist afterwards: the targets that
+// have a todo, in order, including todos that were created but could not be
+// read back, followed by entries that could not be deleted, so
+// the next apply tries again.
+//
+// If keepFailedCreates is set, failed creates are reported as warnings
+// instead, and targets that got no todo are returned in order with an ID of
+// 0, so the caller can save what was written without failing the apply.
+func (w *todoWriter) sync(ctx context.Context, targets []todoSyncTarget, existing []todoSyncEntry, parallelism int, keepFailedCreates bool) ([]todoSyncEntry, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	wanted := make(map[string]bool, len(targets))
+	for _, target := range targets {
+		wanted[target.Key] = true
+	}
+
+	// Delete the todos that are no longer wanted
+	current := make(map[string]todoSyncEntry, len(existing))
+	var unwanted []todoSyncEntry
+	for _, entry := range existing {
+		if wanted[entry.Key] {
+			current[entry.Key] = entry
+		} else {
+			unwanted = append(unwanted, entry)
+		}
+	}
+	deleteErrs := make([]error, len(unwanted))
+	forEachParallel(len(unwanted), parallelism, func(i int) {
+		deleteErrs[i] = w.delete(unwanted[i].ID)
+	})
+
+	var undeleted []todoSyncEntry
+	for i, entry := range unwanted {
+		if err := deleteErrs[i]; err != nil {
+			diags.AddError(
+				"Error Deleting Todo",
+				"Could not delete todo ID "+strconv.FormatInt(entry.ID, 10)+" for "+strconv.Quote(entry.Key)+", unexpected error: "+err.Error(),
+			)
+			undeleted = append(undeleted, entry)
+			continue
+		}
+		tflog.Debug(ctx, "Deleted todo", map[string]any{"ID": entry.ID, "key": entry.Key})
+	}
+
+	// Create or update the todo of each target that differs from its entry
+	ids := make([]int64, len(targets))
+	writeErrs := make([]error, len(targets))
+	forEachParallel(len(targets), parallelism, func(i int) {
+		target := targets[i]
+		entry, ok := current[target.Key]
+		switch {
+		case !ok:
+			var todo todoItem
+			todo, writeErrs[i] = w.create(ctx, target.Description, target.Completed)
+			ids[i] = todo.ID
+		case entry.Description != target.Description || entry.Completed != target.Completed:
+			ids[i] = entry.ID
+			_, writeErrs[i] = w.update(ctx, entry.ID, target.Description, target.Completed)
+		default:
+			ids[i] = entry.ID
+		}
+	})
+
+	synced := make([]todoSyncEntry, 0, len(targets)+len(undeleted))
+	for i, target := range targets {
+		entry, ok := current[target.Key]
+		switch err := writeErrs[i]; {
+		case err != nil && !ok && keepFailedCreates:
+			diags.AddWarning(
+				"Error Creating Todo",
+				"Could not create a todo for "+strconv.Quote(target.Key)+", unexpected error: "+err.Error(),
+			)
+		case err != nil && !ok:
+			diags.AddError(
+				"Error Creating Todo",
+				"Could not create a todo for "+strconv.Quote(target.Key)+", unexpected error: "+err.Error(),
+			)
+			if ids[i] == 0 {
+				continue
+			}
+			// The todo exists, only cleaning up or reading it back failed, so
+			// keep it to avoid creating a duplicate on the next apply
+			synced = append(synced, todoSyncEntry{
+				Key:         target.Key,
+				ID:          ids[i],
+				Description: target.Description,
+				Completed:   target.Completed,
+			})
+			continue
+		case err != nil:
+			diags.AddError(
+				"Error Updating Todo",
+				"Could not update todo ID "+strconv.FormatInt(entry.ID, 10)+" for "+strconv.Quote(target.Key)+", unexpected error: "+err.Error(),
+			)
+			synced = append(synced, entry)
+			continue
+		case !ok:
+			tflog.Debug(ctx, "Created todo", map[string]any{"ID": ids[i], "key": target.Key})
+		case entry.Description != target.Description || entry.Completed != target.Completed:
+			tflog.Debug(ctx, "Updated todo", map[string]any{"ID": ids[i], "key": target.Key})
+		}
+
+		synced = append(synced, todoSyncEntry{
+			Key:         target.Key,
+			ID:          ids[i],
+			Description: target.Description,
+			Completed:   target.Completed,
+		})
+	}
+	return append(synced, undeleted...), diags
+}
+
+// forEachParallel calls fn with every index below n, running at most
+// parallelism calls at a time, and returns once all calls have returned.
+func forEachParallel(n, parallelism int, fn func(i int)) {
+	if parallelism < 1 {
+		parallelism = 1
+	}
+
+	slots := make(chan struct{}, parallelism)
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		slots <- struct{}{}
+		wg.Add(1)
+		go func(i int) {
+			defer func() {
+				<-slots
+				wg.Done()
+			}()
+			fn(i)
+		}(i)
+	}
+	wg.Wait()
+}
+
+// refresh reads the todos of entries back from the server. Entries whose
+// todo no longer exists are dropped, so they are planned to be created again.
+// A description the server only normalized differently is kept as written.
+func (w *todoWriter) refresh(ctx context.Context, entries []todoSyncEntry) ([]todoSyncEntry, error) {
+	refreshed := make([]todoSyncEntry, 0, len(entries))
+	for _, entry := range entries {
+		todo, err := w.read(entry.ID)
+		if err != nil {
+			return nil, err
+		}
+		if todo == nil {
+			tflog.Debug(ctx, "Synced todo no longer exists", map[string]any{"ID": entry.ID, "key": entry.Key})
+			continue
+		}
+
+		if !w.descriptionNormalizer.equal(entry.Description, todo.Description) {
+			entry.Description = todo.Description
+		}
+		entry.Completed = todo.Completed
+		refreshed = append(refreshed, entry)
+	}
+	return refreshed, nil
+}
+
+// scan reads the todos of entries back from the server like refresh, but
+// with a single paged FindTodos scan instead of one read per todo.
+func (w *todoWriter) scan(ctx context.Context, entries []todoSyncEntry) ([]todoSyncEntry, error) {
+	if len(entries) == 0 {
+		return entries, nil
+	}
+
+	wanted := make(map[int64]bool, len(entries))
+	lowest := entries[0].ID
+	for _, entry := range entries {
+		wanted[entry.ID] = true
+		if entry.ID < lowest {
+			lowest = entry.ID
+		}
+	}
+
+	found := make(map[int64]*models.Item, len(entries))
+	err := forEachTodo(ctx, w.client, lowest-1, func(item *models.Item) bool {
+		if wanted[item.ID] && item.Description != nil && item.Completed != nil {
+			found[item.ID] = item
+		}
+		return len(found) < len(wanted)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	refreshed := make([]todoSyncEntry, 0, len(entries))
+	for _, entry := range entries {
+		item, ok := found[entry.ID]
+		if !ok {
+			tflog.Debug(ctx, "Synced todo no longer exists", map[string]any{"ID": entry.ID, "key": entry.Key})
+			continue
+		}
+
+		description, err := w.keyring.decrypt(*item.Description)
+		if err != nil {
+			return nil, fmt.Errorf("todo ID %d: %w", entry.ID, err)
+		}
+		if !w.descriptionNormalizer.equal(entry.Description, description) {
+			entry.Description = description
+		}
+		entry.Completed = *item.Completed
+		refreshed = append(refreshed, entry)
+	}
+	return refreshed, nil
+}
@@ -0,0 +1,111 @@
+package todo
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestForEachParallel(t *testing.T) {
+	var running, peak int32
+	calls := make([]int32, 20)
+
+	forEachParallel(len(calls), 3, func(i int) {
+		current := atomic.AddInt32(&running, 1)
+		for {
+			seen := atomic.LoadInt32(&peak)
+			if current <= seen || atomic.CompareAndSwapInt32(&peak, seen, current) {
+				break
+			}
+		}
+		time.Sleep(time.Millisecond)
+		atomic.AddInt32(&calls[i], 1)
+		atomic.AddInt32(&running, -1)
+	})
+
+	if peak > 3 {
+		t.Errorf("expected at most 3 concurrent calls, got %d", peak)
+	}
+	for i, n := range calls {
+		if n != 1 {
+			t.Errorf("expected index %d to be called once, got %d", i, n)
+		}
+	}
+}
+
+func TestTodoWriterScan(t *testing.T) {
+	w := &todoWriter{client: newTestTodoClient(t, newTestTodoListHandler(t, 250, false))}
+
+	entries := []todoSyncEntry{
+		{Key: "b", ID: 240, Description: "stale", Completed: true},
+		{Key: "a", ID: 120, Description: "todo 120", Completed: false},
+		{Key: "gone", ID: 300, Description: "todo 300", Completed: false},
+	}
+	refreshed, err := w.scan(context.Background(), entries)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []todoSyncEntry{
+		{Key: "b", ID: 240, Description: "todo 240", Completed: false},
+		{Key: "a", ID: 120, Description: "todo 120", Completed: false},
+	}
+	if !reflect.DeepEqual(refreshed, expected) {
+		t.Errorf("expected %+v, got %+v", expected, refreshed)
+	}
+}
+
+func TestTodoWriterSyncKeepsTodosThatCouldNotBeReadBack(t *testing.T) {
+	w := &todoWriter{client: newTestTodoClient(t, func(rw http.ResponseWriter, r *http.Request) {
+		rw.Header().Set("Content-Type", "application/spkane.todo-list.v1+json")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/":
+			fmt.Fprint(rw, `[]`)
+		case r.Method == http.MethodPost:
+			rw.WriteHeader(http.StatusCreated)
+			fmt.Fprint(rw, `{"id": 7, "description": "pending", "completed": false}`)
+		case r.Method == http.MethodPut:
+			fmt.Fprint(rw, `{"id": 7, "description": "Walk the dog", "completed": false}`)
+		default:
+			rw.WriteHeader(http.StatusServiceUnavailable)
+			fmt.Fprint(rw, `{"code": 503, "message": "unavailable"}`)
+		}
+	})}
+
+	targets := []todoSyncTarget{{Key: "dog", Description: "Walk the dog"}}
+	synced, diags := w.sync(context.Background(), targets, nil, 1, false)
+	if !diags.HasError() {
+		t.Error("expected the failed read-back to be reported")
+	}
+	want := []todoSyncEntry{{Key: "dog", ID: 7, Description: "Walk the dog"}}
+	if !reflect.DeepEqual(synced, want) {
+		t.Errorf("expected the created todo to be kept, got %+v", synced)
+	}
+}
+
+func TestTodoWriterSyncKeepsFailedCreates(t *testing.T) {
+	w := &todoWriter{client: newTestTodoClient(t, func(rw http.ResponseWriter, r *http.Request) {
+		rw.Header().Set("Content-Type", "application/spkane.todo-list.v1+json")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/":
+			fmt.Fprint(rw, `[]`)
+		default:
+			rw.WriteHeader(http.StatusServiceUnavailable)
+			fmt.Fprint(rw, `{"code": 503, "message": "unavailable"}`)
+		}
+	})}
+
+	targets := []todoSyncTarget{{Key: "dog", Description: "Walk the dog"}}
+	synced, diags := w.sync(context.Background(), targets, nil, 1, true)
+	if diags.HasError() || diags.WarningsCount() != 1 {
+		t.Errorf("expected the failed create to be a warning, got %v", diags)
+	}
+	want := []todoSyncEntry{{Key: "dog", Description: "Walk the dog"}}
+	if !reflect.DeepEqual(synced, want) {
+		t.Errorf("expected the target to be kept without a todo, got %+v", synced)
+	}
+}
@@ -0,0 +1,128 @@
+package todo
+
+import (
+	"context"
+
+	// Todo API Libraries
+	"github.com/spkane/todo-for-terraform/client"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &todoTodosDataSource{}
+	_ datasource.DataSourceWithConfigure = &todoTodosDataSource{}
+)
+
+// NewTodoTodosDataSource is a helper function to simplify the provider implementation.
+func NewTodoTodosDataSource() datasource.DataSource {
+	return &todoTodosDataSource{}
+}
+
+// todoTodosDataSource is the data source implementation.
+type todoTodosDataSource struct {
+	client  *client.TodoList
+	keyring *todoKeyring
+}
+
+// todoTodosDataSourceModel maps the data source schema data.
+type todoTodosDataSourceModel struct {
+	Filter     *todoFilterModel `tfsdk:"filter"`
+	MaxResults types.Int64      `tfsdk:"max_results"`
+	Todos      []todoItemModel  `tfsdk:"todos"`
+	IDs        []types.Int64    `tfsdk:"ids"`
+	Total      types.Int64      `tfsdk:"total"`
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *todoTodosDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData := req.ProviderData.(*todoProviderData)
+	d.client = providerData.client
+	d.keyring = providerData.keyring
+}
+
+// Metadata returns the data source type name.
+func (d *todoTodosDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_todos"
+}
+
+// Schema defines the schema for the data source.
+func (d *todoTodosDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Fetch every todo matching a filter.",
+		Attributes: map[string]schema.Attribute{
+			"filter": todoFilterDataSourceAttribute(),
+			"max_results": schema.Int64Attribute{
+				Description: "Fail instead of returning more than this many todos (default: 1000).",
+				Optional:    true,
+			},
+			"todos": schema.ListNestedAttribute{
+				Description: "The matching todos, in ascending ID order.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: todoItemDataSourceAttributes(),
+				},
+			},
+			"ids": schema.ListAttribute{
+				Description: "The unique identifiers of the matching todos.",
+				Computed:    true,
+				ElementType: types.Int64Type,
+			},
+			"total": schema.Int64Attribute{
+				Description: "The number of matching todos.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *todoTodosDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	tflog.Debug(ctx, "Preparing to read todos data source")
+	var state todoTodosDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	filter, diags := newTodoFilter(state.Filter, path.Root("filter"))
+	resp.Diagnostics.Append(diags...)
+	maxResults, diags := parseMaxResults(state.MaxResults)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	matched, diags := listTodos(ctx, d.client, d.keyring, filter, maxResults)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Map response body to model
+	state.Todos = []todoItemModel{}
+	state.IDs = []types.Int64{}
+	for _, todo := range matched {
+		state.Todos = append(state.Todos, todoItemModel{
+			ID:          types.Int64Value(todo.ID),
+			Description: types.StringValue(todo.Description),
+			Completed:   types.BoolValue(todo.Completed),
+		})
+		state.IDs = append(state.IDs, types.Int64Value(todo.ID))
+	}
+	state.Total = types.Int64Value(int64(len(matched)))
+
+	// Set state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	tflog.Debug(ctx, "Finished reading todos data source", map[string]any{"count": len(matched)})
+}
@@ -0,0 +1,45 @@
+package todo
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccTodoTodosDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: providerConfig + `
+resource "todo_todo" "open" {
+	description = "[acc-todos] Go Shopping"
+	completed = false
+}
+
+resource "todo_todo" "done" {
+	description = "[acc-todos] Walk the dog"
+	completed = true
+}
+
+data "todo_todos" "test" {
+	filter = {
+		description_prefix = "[acc-todos]"
+		completed          = false
+	}
+
+	depends_on = [todo_todo.open, todo_todo.done]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.todo_todos.test", "total", "1"),
+					resource.TestCheckResourceAttr("data.todo_todos.test", "todos.#", "1"),
+					resource.TestCheckResourceAttr("data.todo_todos.test", "todos.0.description", "[acc-todos] Go Shopping"),
+					resource.TestCheckResourceAttr("data.todo_todos.test", "todos.0.completed", "false"),
+					resource.TestCheckResourceAttrPair("data.todo_todos.test", "ids.0", "todo_todo.open", "id"),
+				),
+			},
+		},
+	})
+}
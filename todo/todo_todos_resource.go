@@ -0,0 +1,354 @@
+package todo
+
+import (
+	"context"
+	"sort"
+
+	"github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// defaultTodosParallelism is how many todos the todo_todos resource writes
+// at a time by default.
+const defaultTodosParallelism = 10
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                   = &todoTodosResource{}
+	_ resource.ResourceWithConfigure      = &todoTodosResource{}
+	_ resource.ResourceWithValidateConfig = &todoTodosResource{}
+)
+
+// todosItemType is the object type of an entry in the items attribute.
+var todosItemType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"description": types.StringType,
+		"completed":   types.BoolType,
+		"id":          types.Int64Type,
+	},
+}
+
+// NewTodoTodosResource is a helper function to simplify the provider implementation.
+func NewTodoTodosResource() resource.Resource {
+	return &todoTodosResource{}
+}
+
+// todoTodosResource is the resource implementation.
+type todoTodosResource struct {
+	writer *todoWriter
+}
+
+// todoTodosResourceModel maps the resource schema data.
+type todoTodosResourceModel struct {
+	ID          types.String `tfsdk:"id"`
+	Items       types.Map    `tfsdk:"items"`
+	Parallelism types.Int64  `tfsdk:"parallelism"`
+}
+
+// todosItemModel maps an entry in the items attribute.
+type todosItemModel struct {
+	Description types.String `tfsdk:"description"`
+	Completed   types.Bool   `tfsdk:"completed"`
+	ID          types.Int64  `tfsdk:"id"`
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *todoTodosResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	r.writer = newTodoWriter(req.ProviderData.(*todoProviderData))
+}
+
+// Metadata returns the resource type name.
+func (r *todoTodosResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_todos"
+}
+
+// Schema defines the schema for the resource.
+func (r *todoTodosResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manage many todos as a single resource, keyed by a name of your choosing. Only the todos whose entry " +
+			"changed are written on update, and all of them are refreshed with a single paged scan of the server, " +
+			"which is much faster than a todo_todo resource per todo.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "A unique identifier for the set of todos.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"items": schema.MapNestedAttribute{
+				Description: "The todos to manage, keyed by a stable name. Adding or removing a key creates or deletes its todo.",
+				Required:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"description": schema.StringAttribute{
+							Description: "The description for the todo.",
+							Required:    true,
+						},
+						"completed": schema.BoolAttribute{
+							Description: "The completed status for the todo.",
+							Required:    true,
+						},
+						"id": schema.Int64Attribute{
+							Description: "The unique identifier for the todo. Null if the todo could not be created, in which case the next apply creates it.",
+							Computed:    true,
+							PlanModifiers: []planmodifier.Int64{
+								int64planmodifier.UseStateForUnknown(),
+							},
+						},
+					},
+				},
+			},
+			"parallelism": schema.Int64Attribute{
+				Description: "How many todos to create, update or delete at a time (default: 10).",
+				Optional:    true,
+			},
+		},
+	}
+}
+
+// ValidateConfig checks that parallelism is positive.
+func (r *todoTodosResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var parallelism types.Int64
+
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("parallelism"), &parallelism)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !parallelism.IsNull() && !parallelism.IsUnknown() && parallelism.ValueInt64() < 1 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("parallelism"),
+			"Invalid Parallelism",
+			"The parallelism must be at least 1.",
+		)
+	}
+}
+
+// Create creates a todo for every item.
+func (r *todoTodosResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	tflog.Debug(ctx, "Preparing to create todo todos resource")
+	// Retrieve values from plan
+	var plan todoTodosResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id, err := uuid.GenerateUUID()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Creating Todos",
+			"Could not generate an ID, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	plan.ID = types.StringValue(id)
+
+	resp.Diagnostics.Append(r.sync(ctx, &plan, nil)...)
+
+	// Save the todos that were created even if others failed, so the
+	// resource is not replaced
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+	tflog.Debug(ctx, "Created todo todos resource", map[string]any{"success": !resp.Diagnostics.HasError()})
+}
+
+// Read refreshes every item with a single scan of the server, dropping the
+// ones whose todo was deleted or could not be created so they are planned to
+// be created again.
+func (r *todoTodosResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	tflog.Debug(ctx, "Preparing to read todo todos resource")
+	// Get current state
+	var state todoTodosResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	items, diags := todosItems(ctx, state.Items)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	entries, err := r.writer.scan(ctx, todosEntries(items))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Todos",
+			"Could not read todos: "+err.Error(),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(state.setItems(ctx, entries)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	tflog.Debug(ctx, "Finished reading todo todos resource", map[string]any{"count": len(entries)})
+}
+
+// Update writes the items that were added, changed or removed.
+func (r *todoTodosResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	tflog.Debug(ctx, "Preparing to update todo todos resource")
+	// Retrieve values from plan
+	var plan todoTodosResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Retrieve the todos that exist from state
+	var state todoTodosResourceModel
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	existing, diags := todosItems(ctx, state.Items)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.sync(ctx, &plan, existing)...)
+
+	// Save the changes that were made even if others failed
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+	tflog.Debug(ctx, "Updated todo todos resource", map[string]any{"success": !resp.Diagnostics.HasError()})
+}
+
+// Delete deletes every item todo.
+func (r *todoTodosResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	tflog.Debug(ctx, "Preparing to delete todo todos resource")
+	// Retrieve values from state
+	var state todoTodosResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	items, diags := todosItems(ctx, state.Items)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	undeleted, diags := r.writer.sync(ctx, nil, todosEntries(items), todosParallelism(state.Parallelism), false)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		// Keep only the todos that could not be deleted
+		resp.Diagnostics.Append(state.setItems(ctx, undeleted)...)
+		resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+	}
+	tflog.Debug(ctx, "Deleted todo todos resource", map[string]any{"success": !resp.Diagnostics.HasError()})
+}
+
+// sync makes the todos on the server match the items in plan, given the
+// items that already have todos, leaving plan with the items that have todos
+// afterwards. Items whose todo could not be created are kept with a null ID
+// and a warning, so the apply does not fail over them.
+func (r *todoTodosResource) sync(ctx context.Context, plan *todoTodosResourceModel, existing map[string]todosItemModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	items, itemDiags := todosItems(ctx, plan.Items)
+	diags.Append(itemDiags...)
+	if diags.HasError() {
+		return diags
+	}
+
+	targets := make([]todoSyncTarget, 0, len(items))
+	for _, entry := range todosEntries(items) {
+		targets = append(targets, todoSyncTarget{
+			Key:         entry.Key,
+			Description: entry.Description,
+			Completed:   entry.Completed,
+		})
+	}
+
+	entries, syncDiags := r.writer.sync(ctx, targets, todosEntries(existing), todosParallelism(plan.Parallelism), true)
+	diags.Append(syncDiags...)
+
+	diags.Append(plan.setItems(ctx, entries)...)
+	return diags
+}
+
+// todosParallelism returns the parallelism, defaulting to
+// defaultTodosParallelism.
+func todosParallelism(value types.Int64) int {
+	if value.IsNull() {
+		return defaultTodosParallelism
+	}
+	return int(value.ValueInt64())
+}
+
+// todosEntries converts items to sync entries, in key order. Items with a
+// null ID have no todo and are left out.
+func todosEntries(items map[string]todosItemModel) []todoSyncEntry {
+	entries := make([]todoSyncEntry, 0, len(items))
+	for key, item := range items {
+		if item.ID.IsNull() {
+			continue
+		}
+		entries = append(entries, todoSyncEntry{
+			Key:         key,
+			ID:          item.ID.ValueInt64(),
+			Description: item.Description.ValueString(),
+			Completed:   item.Completed.ValueBool(),
+		})
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Key < entries[j].Key })
+	return entries
+}
+
+// todosItems converts the items attribute to its entries. Null and unknown
+// maps have no entries.
+func todosItems(ctx context.Context, value types.Map) (map[string]todosItemModel, diag.Diagnostics) {
+	items := map[string]todosItemModel{}
+	if value.IsNull() || value.IsUnknown() {
+		return items, nil
+	}
+	diags := value.ElementsAs(ctx, &items, false)
+	return items, diags
+}
+
+// setItems sets the items attribute from sync entries. Entries with an ID
+// of 0 have no todo and get a null ID.
+func (m *todoTodosResourceModel) setItems(ctx context.Context, entries []todoSyncEntry) diag.Diagnostics {
+	items := make(map[string]todosItemModel, len(entries))
+	for _, entry := range entries {
+		id := types.Int64Value(entry.ID)
+		if entry.ID == 0 {
+			id = types.Int64Null()
+		}
+		items[entry.Key] = todosItemModel{
+			Description: types.StringValue(entry.Description),
+			Completed:   types.BoolValue(entry.Completed),
+			ID:          id,
+		}
+	}
+
+	value, diags := types.MapValueFrom(ctx, todosItemType, items)
+	m.Items = value
+	return diags
+}
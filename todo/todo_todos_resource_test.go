@@ -0,0 +1,64 @@
+package todo
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccTodoTodosResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: providerConfig + `
+resource "todo_todos" "test" {
+	items = {
+		shopping = {
+			description = "Go Shopping"
+			completed   = false
+		}
+		dog = {
+			description = "Walk the dog"
+			completed   = true
+		}
+	}
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("todo_todos.test", "items.%", "2"),
+					resource.TestCheckResourceAttr("todo_todos.test", "items.shopping.description", "Go Shopping"),
+					resource.TestCheckResourceAttr("todo_todos.test", "items.dog.completed", "true"),
+					resource.TestCheckResourceAttrSet("todo_todos.test", "items.shopping.id"),
+					resource.TestCheckResourceAttrSet("todo_todos.test", "id"),
+				),
+			},
+			// Update and Read testing
+			{
+				Config: providerConfig + `
+resource "todo_todos" "test" {
+	items = {
+		shopping = {
+			description = "Go Shopping"
+			completed   = true
+		}
+		laundry = {
+			description = "Do the laundry"
+			completed   = false
+		}
+	}
+	parallelism = 2
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("todo_todos.test", "items.%", "2"),
+					resource.TestCheckResourceAttr("todo_todos.test", "items.shopping.completed", "true"),
+					resource.TestCheckResourceAttr("todo_todos.test", "items.laundry.description", "Do the laundry"),
+					resource.TestCheckNoResourceAttr("todo_todos.test", "items.dog.id"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
@@ -0,0 +1,281 @@
+package todo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	// Todo API Libraries
+	"github.com/spkane/todo-for-terraform/client"
+	"github.com/spkane/todo-for-terraform/client/todos"
+	"github.com/spkane/todo-for-terraform/models"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// todoWriter writes individual todos on behalf of resources that manage
+// several of them. It applies the same idempotent creates, encryption and
+// read-back consistency checks as the todo_todo resource.
+type todoWriter struct {
+	client                *client.TodoList
+	keyring               *todoKeyring
+	consistencyTimeout    time.Duration
+	descriptionNormalizer *descriptionNormalizer
+	// orphans adopts the todos left behind by earlier failed creates.
+	orphans orphanAdopter
+}
+
+// newTodoWriter returns a todoWriter using the provider configuration.
+func newTodoWriter(providerData *todoProviderData) *todoWriter {
+	return &todoWriter{
+		client:                providerData.client,
+		keyring:               providerData.keyring,
+		consistencyTimeout:    providerData.consistencyTimeout,
+		descriptionNormalizer: providerData.descriptionNormalizer,
+	}
+}
+
+// create adds a todo and waits for it to read back as written.
+func (w *todoWriter) create(ctx context.Context, description string, completed bool) (todoItem, error) {
+	encrypted, err := w.keyring.encrypt(description)
+	if err != nil {
+		return todoItem{}, err
+	}
+
+	id, err := w.addOrAdopt(ctx, encrypted, completed, func(stored string) bool {
+		plaintext, err := w.keyring.decrypt(stored)
+		return err == nil && plaintext == description
+	})
+	if err != nil {
+		return todoItem{}, err
+	}
+
+	// Remove the pending marker now that we know the todo's ID
+	if err := w.write(id, encrypted, completed); err != nil {
+		return todoItem{ID: id}, fmt.Errorf("could not finish creating todo ID %d, it is left behind as an orphan "+
+			"that the todo_orphans data source lists: %w", id, err)
+	}
+	return w.wait(ctx, id, description, completed)
+}
+
+// update overwrites a todo and waits for it to read back as written.
+func (w *todoWriter) update(ctx context.Context, id int64, description string, completed bool) (todoItem, error) {
+	encrypted, err := w.keyring.encrypt(description)
+	if err != nil {
+		return todoItem{}, err
+	}
+	if err := w.write(id, encrypted, completed); err != nil {
+		return todoItem{}, err
+	}
+	return w.wait(ctx, id, description, completed)
+}
+
+// delete removes a todo. A todo that is already gone is not an error.
+func (w *todoWriter) delete(id int64) error {
+	params := todos.NewDestroyOneParams()
+	params.SetID(id)
+	_, err := w.client.Todos.DestroyOne(params)
+	if err != nil && !isTodoNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+// read returns a todo with its plaintext description, or nil if there is no
+// todo with the ID.
+func (w *todoWriter) read(id int64) (*todoItem, error) {
+	item, err := w.readStored(id)
+	if item == nil || err != nil {
+		return nil, err
+	}
+	description, err := w.keyring.decrypt(*item.Description)
+	if err != nil {
+		return nil, err
+	}
+	return &todoItem{
+		ID:          item.ID,
+		Description: description,
+		Completed:   *item.Completed,
+	}, nil
+}
+
+// readStored returns a todo as it is stored on the server, with its
+// description still encrypted, or nil if there is no todo with the ID.
+func (w *todoWriter) readStored(id int64) (*models.Item, error) {
+	params := todos.NewFindTodoParams()
+	params.SetID(id)
+	result, err := w.client.Todos.FindTodo(params)
+	if isTodoNotFound(err) || (err == nil && len(result.GetPayload()) == 0) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	item := result.GetPayload()[0]
+	if item.Description == nil || item.Completed == nil {
+		return nil, nil
+	}
+	return item, nil
+}
+
+// setCompleted changes the completed status of a todo, writing its stored
+// description back as-is, and waits for it to read back. It returns the
+// completed status the todo had before, or nil if there is no todo with the
+// ID.
+func (w *todoWriter) setCompleted(ctx context.Context, id int64, completed bool) (*bool, error) {
+	item, err := w.readStored(id)
+	if item == nil || err != nil {
+		return nil, err
+	}
+
+	previous := *item.Completed
+	if previous == completed {
+		return &previous, nil
+	}
+	if err := w.write(id, *item.Description, completed); err != nil {
+		return &previous, err
+	}
+	_, err = waitForTodo(ctx, w.client, id, w.consistencyTimeout, func(item *models.Item) (bool, error) {
+		return item.Completed != nil && *item.Completed == completed, nil
+	})
+	if err != nil {
+		return &previous, fmt.Errorf("could not read back todo ID %d: %w", id, err)
+	}
+	return &previous, nil
+}
+
+// createStored adds a todo with a description that is stored as-is,
+// without encryption, and waits for it to read back. If the todo was added
+// but could not be finished, its ID is returned with the error.
+func (w *todoWriter) createStored(ctx context.Context, description string, completed bool) (int64, error) {
+	id, err := w.addOrAdopt(ctx, description, completed, func(stored string) bool {
+		return stored == description
+	})
+	if err != nil {
+		return 0, err
+	}
+	if err := w.writeStored(ctx, id, description, completed); err != nil {
+		return id, fmt.Errorf("could not finish creating todo ID %d: %w", id, err)
+	}
+	return id, nil
+}
+
+// writeStored overwrites a todo with a description that is stored as-is,
+// without encryption, and waits for it to read back.
+func (w *todoWriter) writeStored(ctx context.Context, id int64, description string, completed bool) error {
+	if err := w.write(id, description, completed); err != nil {
+		return err
+	}
+	_, err := waitForTodo(ctx, w.client, id, w.consistencyTimeout, func(item *models.Item) (bool, error) {
+		return item.Description != nil && *item.Description == description &&
+			item.Completed != nil && *item.Completed == completed, nil
+	})
+	if err != nil {
+		return fmt.Errorf("could not read back todo ID %d: %w", id, err)
+	}
+	return nil
+}
+
+// write stores an already encrypted description with UpdateOne.
+func (w *todoWriter) write(id int64, description string, completed bool) error {
+	params := todos.NewUpdateOneParams()
+	params.SetID(id)
+	params.SetBody(&models.Item{
+		Description: &description,
+		Completed:   &completed,
+	})
+	_, err := w.client.Todos.UpdateOne(params)
+	return err
+}
+
+// wait reads a todo back until it matches what was written.
+func (w *todoWriter) wait(ctx context.Context, id int64, description string, completed bool) (todoItem, error) {
+	item, err := waitForTodo(ctx, w.client, id, w.consistencyTimeout, func(item *models.Item) (bool, error) {
+		if item.Description == nil || item.Completed == nil {
+			return false, nil
+		}
+		plaintext, err := w.keyring.decrypt(*item.Description)
+		if err != nil {
+			return false, err
+		}
+		return w.descriptionNormalizer.equal(description, plaintext) && *item.Completed == completed, nil
+	})
+	if err != nil {
+		return todoItem{ID: id}, fmt.Errorf("could not read back todo ID %d: %w", id, err)
+	}
+
+	plaintext, err := w.keyring.decrypt(*item.Description)
+	if err != nil {
+		return todoItem{ID: id}, err
+	}
+	return todoItem{
+		ID:          item.ID,
+		Description: plaintext,
+		Completed:   *item.Completed,
+	}, nil
+}
+
+// addOrAdopt adds a todo with an already encrypted description, tagged
+// with a pending marker, and returns its ID. If an earlier create of the
+// same todo left it behind, as identified by matches, that todo is adopted
+// instead of adding a second copy. The caller removes the pending marker.
+func (w *todoWriter) addOrAdopt(ctx context.Context, description string, completed bool, matches func(stored string) bool) (int64, error) {
+	orphan, err := w.orphans.adopt(ctx, w.client, completed, matches)
+	if err != nil {
+		return 0, err
+	}
+	if orphan != nil {
+		tflog.Info(ctx, "Adopting todo left behind by an earlier create", map[string]any{"ID": orphan.ID})
+		return orphan.ID, nil
+	}
+
+	// Tag the todo with an idempotency token while it is being created,
+	// so it can be found again if the AddOne response is lost
+	token, err := newIdempotencyToken()
+	if err != nil {
+		return 0, err
+	}
+	pendingDescription := withPendingMarker(description, token)
+
+	params := todos.NewAddOneParams()
+	params.SetBody(&models.Item{
+		Description: &pendingDescription,
+		Completed:   &completed,
+	})
+	return addTodo(ctx, w.client, params, token)
+}
+
+// addTodo creates a todo with AddOne, retrying failed attempts. After each
+// failure the todo is looked up by its idempotency token and adopted if the
+// attempt did succeed but its response was lost.
+func addTodo(ctx context.Context, c *client.TodoList, params *todos.AddOneParams, token string) (int64, error) {
+	var err error
+	for attempt := 1; attempt <= createAttempts; attempt++ {
+		var result *todos.AddOneCreated
+		result, err = c.Todos.AddOne(params)
+		if err == nil {
+			return result.GetPayload().ID, nil
+		}
+
+		existing, findErr := findPendingTodo(ctx, c, token)
+		if findErr == nil && existing != nil {
+			tflog.Info(ctx, "Adopting todo created by an attempt whose response was lost", map[string]any{
+				"ID":      existing.ID,
+				"attempt": attempt})
+			return existing.ID, nil
+		}
+
+		tflog.Warn(ctx, "Error creating todo", map[string]any{
+			"attempt": attempt,
+			"Error":   err.Error()})
+		if attempt < createAttempts {
+			select {
+			case <-ctx.Done():
+				return 0, ctx.Err()
+			case <-time.After(time.Duration(attempt) * time.Second):
+			}
+		}
+	}
+	return 0, err
+}